@@ -0,0 +1,59 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetCellIDStringFromCoords returns the A1-style cell reference for the
+// given zero-indexed column and row, e.g. GetCellIDStringFromCoords(0, 0)
+// == "A1".
+func GetCellIDStringFromCoords(col, row int) string {
+	return ColIndexToLetters(col) + strconv.Itoa(row+1)
+}
+
+// GetCoordsFromCellIDString parses an A1-style cell reference such as "C7"
+// back into its zero-indexed column and row.
+func GetCoordsFromCellIDString(cellID string) (col, row int, err error) {
+	i := 0
+	for i < len(cellID) && (cellID[i] < '0' || cellID[i] > '9') {
+		i++
+	}
+	if i == 0 || i == len(cellID) {
+		return 0, 0, fmt.Errorf("xlsx: invalid cell reference %q", cellID)
+	}
+	col = LettersToColIndex(cellID[:i])
+	rowNum, err := strconv.Atoi(cellID[i:])
+	if err != nil {
+		return 0, 0, fmt.Errorf("xlsx: invalid cell reference %q: %w", cellID, err)
+	}
+	return col, rowNum - 1, nil
+}
+
+// ColIndexToLetters converts a zero-indexed column number into its
+// spreadsheet column letters, e.g. 0 -> "A", 26 -> "AA".
+func ColIndexToLetters(col int) string {
+	var b strings.Builder
+	col++
+	for col > 0 {
+		col--
+		b.WriteByte(byte('A' + col%26))
+		col /= 26
+	}
+	letters := []byte(b.String())
+	for i, j := 0, len(letters)-1; i < j; i, j = i+1, j-1 {
+		letters[i], letters[j] = letters[j], letters[i]
+	}
+	return string(letters)
+}
+
+// LettersToColIndex converts spreadsheet column letters (e.g. "AA") into
+// their zero-indexed column number.
+func LettersToColIndex(letters string) int {
+	col := 0
+	for i := 0; i < len(letters); i++ {
+		col = col*26 + int(letters[i]-'A'+1)
+	}
+	return col - 1
+}
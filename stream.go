@@ -0,0 +1,324 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// CellType identifies how a streamed cell's value is encoded in the
+// worksheet XML. It mirrors the subset of the in-memory package's cell
+// types that the streaming writer/reader need to reason about.
+type CellType int
+
+const (
+	CellTypeString CellType = iota
+	CellTypeStringFormula
+	CellTypeNumeric
+	CellTypeBool
+	CellTypeInline
+	CellTypeError
+	CellTypeDate
+	CellTypeGeneral
+	CellTypeFormula
+)
+
+// Ptr returns a pointer to a copy of c, for use in the []*CellType column
+// declarations AddSheet accepts.
+func (c CellType) Ptr() *CellType {
+	return &c
+}
+
+// fallbackTo resolves the concrete type a streamed cell should be encoded
+// as: CellTypeString columns are always written as inline strings (the
+// streaming writer has no shared-string table unless UseSharedStrings is
+// enabled), and every other declared type is used as-is.
+func (c CellType) fallbackTo(value string, fallback CellType) CellType {
+	switch c {
+	case CellTypeString:
+		return CellTypeInline
+	default:
+		return c
+	}
+}
+
+// BuiltStreamFileBuilderError is returned by any StreamFileBuilder method
+// that mutates builder state once Build has already been called.
+var BuiltStreamFileBuilderError = errors.New("xlsx: StreamFileBuilder has already built a StreamFile; no further configuration is allowed")
+
+// initMaxStyleId is the highest cellXfs index taken by the built-in default
+// styles every workbook's styles.xml ships with (indices 0 through
+// initMaxStyleId), before any AddStyle-registered styles are added.
+const initMaxStyleId = 1
+
+// StreamFileBuilder assembles the sheets, styles, and workbook-level
+// features (compression, shared strings, tables, conditional formatting...)
+// of an XLSX file before any row is written, then hands off a StreamFile
+// that streams the actual cell data out.
+type StreamFileBuilder struct {
+	zipWriter  *zip.Writer
+	file       *os.File
+	built      bool
+	sheetNames []string
+
+	sheetHeadersMap map[string][]string
+	sheetCellTypes  map[string][]*CellType
+
+	registeredStyles     map[int]bool
+	pendingStyles        []*Style
+	pendingNumberFormats []numberFormat
+
+	compressionLevel CompressionLevel
+
+	usesDynamicArrays bool
+
+	formattingRules map[string]*streamFormattingRules
+
+	sheetViews map[string]*streamSheetView
+
+	sharedStrings *streamSharedStrings
+
+	tables map[string]*streamTable
+}
+
+// NewStreamFileBuilder begins building a workbook that will be written to
+// writer. writer need not support Seek; the zip archive is written in a
+// single forward pass.
+func NewStreamFileBuilder(writer io.Writer) *StreamFileBuilder {
+	return &StreamFileBuilder{zipWriter: zip.NewWriter(writer)}
+}
+
+// NewStreamFileBuilderForPath is NewStreamFileBuilder, but creates (or
+// truncates) the file at path and writes the workbook there.
+func NewStreamFileBuilderForPath(path string) (*StreamFileBuilder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	sb := NewStreamFileBuilder(f)
+	sb.file = f
+	return sb, nil
+}
+
+// AddSheet declares a worksheet with the given header row and, optionally,
+// a CellType for each column (nil entries default to CellTypeString). Every
+// sheet must be declared before Build is called.
+func (sb *StreamFileBuilder) AddSheet(name string, headers []string, cellTypes []*CellType) error {
+	if sb.built {
+		return BuiltStreamFileBuilderError
+	}
+	sb.sheetNames = append(sb.sheetNames, name)
+	if sb.sheetHeadersMap == nil {
+		sb.sheetHeadersMap = map[string][]string{}
+	}
+	sb.sheetHeadersMap[name] = headers
+	if sb.sheetCellTypes == nil {
+		sb.sheetCellTypes = map[string][]*CellType{}
+	}
+	sb.sheetCellTypes[name] = cellTypes
+	return nil
+}
+
+// Build finalizes the builder's configuration and returns a StreamFile
+// ready to stream rows via NextSheet/Write. No further AddSheet, AddStyle,
+// AddConditionalFormat, etc. calls are allowed once Build has run.
+func (sb *StreamFileBuilder) Build() (*StreamFile, error) {
+	if sb.built {
+		return nil, BuiltStreamFileBuilderError
+	}
+	sb.built = true
+	registerCompressor(sb.zipWriter, sb.compressionLevel)
+	return &StreamFile{builder: sb, archive: sb.zipWriter, sheetIndex: -1}, nil
+}
+
+// StreamFile streams a workbook's rows out to the zip archive a
+// StreamFileBuilder opened, one sheet and one row at a time.
+type StreamFile struct {
+	builder *StreamFileBuilder
+	archive *zip.Writer
+
+	currentWriter    io.Writer
+	currentSheetName string
+	currentSheet     *streamSheetState
+	sheetIndex       int
+
+	closed bool
+}
+
+// streamSheetState tracks the streaming progress of the sheet currently
+// being written: how many rows have been flushed (for merge-overlap
+// validation) and the column metadata it was declared with.
+type streamSheetState struct {
+	rowIndex      int // 1-indexed row number the next Write call will use
+	maxRowWritten int // highest row index flushed so far
+	headers       []string
+	cellTypes     []*CellType
+}
+
+// NextSheet closes out the sheet currently being written (if any) and opens
+// the next one declared via AddSheet/AddSheetS, writing its header row.
+func (sf *StreamFile) NextSheet() error {
+	if err := sf.closeCurrentSheetPart(); err != nil {
+		return err
+	}
+	sf.sheetIndex++
+	if sf.sheetIndex >= len(sf.builder.sheetNames) {
+		return fmt.Errorf("xlsx: NextSheet called but all %d declared sheets have been written", len(sf.builder.sheetNames))
+	}
+
+	name := sf.builder.sheetNames[sf.sheetIndex]
+	sf.currentSheetName = name
+	sf.currentSheet = &streamSheetState{
+		rowIndex:  1,
+		headers:   sf.builder.sheetHeadersMap[name],
+		cellTypes: sf.builder.sheetCellTypes[name],
+	}
+
+	header := &zip.FileHeader{Name: fmt.Sprintf("xl/worksheets/sheet%d.xml", sf.sheetIndex+1)}
+	forceZIP64(header)
+	w, err := sf.archive.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	sf.currentWriter = w
+
+	io.WriteString(w, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	io.WriteString(w, `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" `+
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+
+	if view := sf.builder.sheetViews[name]; view != nil {
+		io.WriteString(w, view.sheetViewXML())
+	}
+	io.WriteString(w, `<sheetData>`)
+
+	if len(sf.currentSheet.headers) > 0 {
+		if err := sf.Write(sf.currentSheet.headers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeCurrentSheetPart writes the trailing, schema-ordered elements
+// (autoFilter, mergeCells, conditionalFormatting, dataValidations,
+// tableParts) and closes the worksheet part currently open, if there is
+// one.
+func (sf *StreamFile) closeCurrentSheetPart() error {
+	if sf.currentWriter == nil {
+		return nil
+	}
+	w := sf.currentWriter
+	io.WriteString(w, `</sheetData>`)
+
+	if view := sf.builder.sheetViews[sf.currentSheetName]; view != nil {
+		io.WriteString(w, view.autoFilterXML())
+		io.WriteString(w, view.mergeCellsXML())
+	}
+	if rules := sf.builder.formattingRules[sf.currentSheetName]; rules != nil {
+		buf := &bytes.Buffer{}
+		writeFormattingRules(buf, rules)
+		w.Write(buf.Bytes())
+	}
+	if table := sf.builder.tables[sf.currentSheetName]; table != nil {
+		io.WriteString(w, tablePartsXML(table, fmt.Sprintf("rIdTable%d", sf.sheetIndex+1)))
+	}
+	io.WriteString(w, `</worksheet>`)
+	sf.currentWriter = nil
+	return nil
+}
+
+// writeRawRow appends an already-serialized <row>...</row> element to the
+// sheet currently being streamed and advances its row bookkeeping.
+func (sf *StreamFile) writeRawRow(rowXML []byte) error {
+	if sf.currentWriter == nil {
+		return errors.New("xlsx: no sheet is open; call NextSheet before writing rows")
+	}
+	if _, err := sf.currentWriter.Write(rowXML); err != nil {
+		return err
+	}
+	sf.currentSheet.maxRowWritten = sf.currentSheet.rowIndex
+	sf.currentSheet.rowIndex++
+	return nil
+}
+
+// Write streams a single row of plain string values, using each column's
+// declared CellType (or CellTypeString if none was given) to decide how the
+// value is encoded.
+func (sf *StreamFile) Write(cells []string) error {
+	if sf.currentSheet == nil {
+		return errors.New("xlsx: Write called before NextSheet")
+	}
+	return sf.writeFastRow(cells, sf.currentSheet.cellTypes)
+}
+
+// WriteWithDefaultCellType streams a single row of plain string values,
+// inferring each column's type from its header cell type the same way
+// Write does; it exists separately because some callers need to assert
+// that behavior explicitly rather than relying on Write's default.
+func (sf *StreamFile) WriteWithDefaultCellType(cells []string) error {
+	return sf.Write(cells)
+}
+
+// Close finishes streaming: it closes out the sheet currently open (if
+// any), writes the workbook-level parts that depend on builder state
+// accumulated across every sheet (styles, shared strings, dynamic-array
+// metadata, tables), and closes the underlying zip archive.
+func (sf *StreamFile) Close() error {
+	if sf.closed {
+		return nil
+	}
+	sf.closed = true
+
+	if err := sf.closeCurrentSheetPart(); err != nil {
+		return err
+	}
+
+	// styles.xml is written unconditionally: every cell this package writes
+	// carries an s="..." attribute referencing it, even when no AddStyle/
+	// AddNumberFormat call ever registered anything beyond the built-in
+	// default style.
+	if err := writeStylesPart(sf.archive, sf.builder.pendingStyles, sf.builder.pendingNumberFormats); err != nil {
+		return err
+	}
+	if sf.builder.sharedStrings != nil {
+		if err := writeSharedStringsPart(sf.archive, sf.builder.sharedStrings); err != nil {
+			return err
+		}
+	}
+	if sf.builder.usesDynamicArrays {
+		if err := writeDynamicArrayMetadataPart(sf.archive); err != nil {
+			return err
+		}
+	}
+	for i, name := range sf.builder.sheetNames {
+		table := sf.builder.tables[name]
+		if table == nil {
+			continue
+		}
+		w, err := sf.archive.Create(fmt.Sprintf("xl/tables/table%d.xml", i+1))
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, tableXML(i+1, table)); err != nil {
+			return err
+		}
+		if err := writeSheetRelsPart(sf.archive, i, fmt.Sprintf("rIdTable%d", i+1)); err != nil {
+			return err
+		}
+	}
+
+	if err := writePackageParts(sf.archive, sf.builder); err != nil {
+		return err
+	}
+
+	if err := sf.archive.Close(); err != nil {
+		return err
+	}
+	if sf.builder.file != nil {
+		return sf.builder.file.Close()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+const (
+	dynamicArrayContentType  = "application/vnd.ms-excel.sheetMetadata+xml"
+	dynamicArrayMetadataPath = "xl/metadata.xml"
+	dynamicArrayRelType      = "http://schemas.microsoft.com/office/2017/10/relationships/sheetMetadata"
+)
+
+// ArrayFormulaCell is a streamed cell whose value is a dynamic-array (spilled)
+// formula such as =UNIQUE(A2:A100) or =SORT(...). It is written with
+// StreamFile.WriteRichRow wherever a plain string or RichText cell would go.
+type ArrayFormulaCell struct {
+	Formula string
+	Ref     string // the spill range, e.g. "A1:C3"
+}
+
+// NewArrayFormulaCell builds a cell carrying a dynamic-array formula that
+// spills into ref when opened in a version of Excel that supports it.
+func NewArrayFormulaCell(formula, ref string) ArrayFormulaCell {
+	return ArrayFormulaCell{Formula: formula, Ref: ref}
+}
+
+// xlsxCellXML renders the <c>...</c> body for an array formula cell,
+// including the cm="1" metadata-record attribute the XLSX schema requires
+// whenever a cell references xl/metadata.xml.
+func (a ArrayFormulaCell) xlsxCellXML(cellRef string, styleID int) string {
+	var formula bytes.Buffer
+	xml.EscapeText(&formula, []byte(a.Formula))
+	return fmt.Sprintf(
+		`<c r="%s" s="%d" cm="1"><f t="array" ref="%s" aca="1">%s</f></c>`,
+		cellRef, styleID, a.Ref, formula.String(),
+	)
+}
+
+// registerDynamicArrayUsage marks the builder as needing xl/metadata.xml,
+// its workbook relationship, and its [Content_Types].xml override, each
+// written exactly once by Close, no matter how many array-formula cells are
+// streamed across sheets. The worksheet root element gets no xr namespace
+// declaration.
+func (sb *StreamFileBuilder) registerDynamicArrayUsage() {
+	sb.usesDynamicArrays = true
+}
+
+// dynamicArrayMetadataXML is the single metadata part registered under
+// xl/metadata.xml once any sheet in the workbook contains a dynamic-array
+// formula. Excel only requires one futureMetadata/cellMetadata record set
+// per workbook, shared by every array-formula cell.
+const dynamicArrayMetadataXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+	`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray">` +
+	`<metadataTypes count="1"><metadataType name="XLDAPR" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1" cellMeta="1"/></metadataTypes>` +
+	`<futureMetadata name="XLDAPR" count="1"><bk><extLst><ext uri="{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/></ext></extLst></bk></futureMetadata>` +
+	`<cellMetadata count="1"><bk><rc t="1" v="0"/></bk></cellMetadata>` +
+	`</metadata>`
+
+// writeDynamicArrayMetadataPart writes xl/metadata.xml into the workbook
+// zip. It is called by StreamFile.Close exactly once, only when
+// usesDynamicArrays is true.
+func writeDynamicArrayMetadataPart(zw *zip.Writer) error {
+	w, err := zw.Create(dynamicArrayMetadataPath)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(dynamicArrayMetadataXML))
+	return err
+}
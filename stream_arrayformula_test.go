@@ -0,0 +1,60 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamArrayFormulaSuite struct{}
+
+var _ = Suite(&StreamArrayFormulaSuite{})
+
+func (s *StreamArrayFormulaSuite) TestArrayFormulaCellRegistersMetadataPart(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Values"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+
+	t.Assert(sf.builder.usesDynamicArrays, Equals, false)
+	err = sf.WriteRichRow([]interface{}{NewArrayFormulaCell("=UNIQUE(A2:A100)", "A2:A4")})
+	t.Assert(err, IsNil)
+	t.Assert(sf.builder.usesDynamicArrays, Equals, true)
+	t.Assert(sf.Close(), IsNil)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	t.Assert(err, IsNil)
+	var found bool
+	for _, f := range zr.File {
+		if f.Name == dynamicArrayMetadataPath {
+			found = true
+			rc, err := f.Open()
+			t.Assert(err, IsNil)
+			data, err := io.ReadAll(rc)
+			t.Assert(err, IsNil)
+			rc.Close()
+			t.Assert(bytes.Contains(data, []byte("XLDAPR")), Equals, true)
+		}
+	}
+	t.Assert(found, Equals, true, Commentf("expected %s to be written once a dynamic-array cell was streamed", dynamicArrayMetadataPath))
+}
+
+func (s *StreamArrayFormulaSuite) TestNoMetadataPartWithoutArrayFormulas(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Values"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+	t.Assert(sf.Close(), IsNil)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	t.Assert(err, IsNil)
+	for _, f := range zr.File {
+		t.Assert(f.Name, Not(Equals), dynamicArrayMetadataPath)
+	}
+}
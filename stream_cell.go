@@ -0,0 +1,150 @@
+package xlsx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// StreamCell is one cell in a row written through StreamFile.WriteCells. It
+// lets a caller mix numbers, dates, booleans, formulas, and strings in a
+// single row and assign each cell its own pre-registered style, instead of
+// Write's single inferred-or-declared type per column.
+type StreamCell struct {
+	Value   interface{} // string, bool, time.Time, or any Go numeric type
+	Type    CellType
+	StyleID int // must refer to a style returned by AddStyle, or 0
+	Formula string
+}
+
+// NewStringStreamCell builds a plain string StreamCell with the given style.
+func NewStringStreamCell(value string, styleID int) StreamCell {
+	return StreamCell{Value: value, Type: CellTypeString, StyleID: styleID}
+}
+
+// NewNumericStreamCell builds a numeric StreamCell with the given style.
+func NewNumericStreamCell(value float64, styleID int) StreamCell {
+	return StreamCell{Value: value, Type: CellTypeNumeric, StyleID: styleID}
+}
+
+// NewDateStreamCell builds a StreamCell holding a time.Time, serialized in
+// XLSX's native numeric date form. The cell displays as a date only if
+// styleID refers to a style with a date number format; no date format is
+// applied otherwise.
+func NewDateStreamCell(value time.Time, styleID int) StreamCell {
+	return StreamCell{Value: value, Type: CellTypeDate, StyleID: styleID}
+}
+
+// NewBoolStreamCell builds a StreamCell holding a boolean.
+func NewBoolStreamCell(value bool, styleID int) StreamCell {
+	return StreamCell{Value: value, Type: CellTypeBool, StyleID: styleID}
+}
+
+// NewFormulaStreamCell builds a StreamCell whose value is a formula, e.g.
+// "=SUM(A1:A10)".
+func NewFormulaStreamCell(formula string, styleID int) StreamCell {
+	return StreamCell{Formula: formula, StyleID: styleID}
+}
+
+// WriteCells writes a single row from a slice of StreamCell, resolving each
+// cell's native XLSX form from its Value/Type and using its StyleID
+// directly rather than the default style the plain Write path uses. Every
+// non-zero StyleID must have been registered up front with AddStyle.
+func (sf *StreamFile) WriteCells(cells []StreamCell) error {
+	if sf.currentSheet == nil {
+		return errors.New("xlsx: WriteCells called before NextSheet")
+	}
+	for _, cell := range cells {
+		if cell.StyleID != 0 && !sf.builder.styleRegistered(cell.StyleID) {
+			return fmt.Errorf("xlsx: StreamCell StyleID %d was never registered with AddStyle", cell.StyleID)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	rowIndex := sf.currentSheet.rowIndex
+	buf.WriteString(fmt.Sprintf(`<row r="%d">`, rowIndex))
+	for i, cell := range cells {
+		ref := GetCellIDStringFromCoords(i, rowIndex-1)
+		if cell.Formula != "" {
+			fmt.Fprintf(buf, `<c r="%s" s="%d"><f>`, ref, cell.StyleID)
+			xmlEscapeTo(buf, cell.Formula)
+			buf.WriteString(`</f></c>`)
+			continue
+		}
+		writeTypedCellValue(buf, ref, cell)
+	}
+	buf.WriteString(`</row>`)
+	return sf.writeRawRow(buf.Bytes())
+}
+
+func writeTypedCellValue(buf *bytes.Buffer, ref string, cell StreamCell) {
+	switch cell.Type {
+	case CellTypeNumeric:
+		fmt.Fprintf(buf, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.StyleID, formatNumericValue(cell.Value))
+	case CellTypeBool:
+		v := 0
+		if b, _ := cell.Value.(bool); b {
+			v = 1
+		}
+		fmt.Fprintf(buf, `<c r="%s" s="%d" t="b"><v>%d</v></c>`, ref, cell.StyleID, v)
+	case CellTypeDate:
+		t, _ := cell.Value.(time.Time)
+		fmt.Fprintf(buf, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.StyleID, formatExcelDate(t))
+	default:
+		fmt.Fprintf(buf, `<c r="%s" s="%d" t="inlineStr"><is><t>`, ref, cell.StyleID)
+		xmlEscapeTo(buf, fmt.Sprintf("%v", cell.Value))
+		buf.WriteString(`</t></is></c>`)
+	}
+}
+
+// formatNumericValue renders any Go numeric type as the decimal text XLSX
+// expects inside a cell's <v>.
+func formatNumericValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// formatExcelDate converts t to the floating-point day count XLSX uses for
+// dates, based at the platform's 1900 epoch (with the historical Lotus leap
+// year bug Excel preserves for compatibility).
+func formatExcelDate(t time.Time) string {
+	const excelEpochDays = 25569 // days between 1899-12-30 and the Unix epoch
+	days := float64(t.Unix())/86400 + excelEpochDays
+	return strconv.FormatFloat(days, 'f', 10, 64)
+}
+
+// styleRegistered reports whether styleID was handed out by AddStyle.
+// registeredStyles is populated by that method.
+func (sb *StreamFileBuilder) styleRegistered(styleID int) bool {
+	return sb.registeredStyles[styleID]
+}
+
+// xmlEscapeTo writes s to buf with the standard XML entity escapes applied.
+func xmlEscapeTo(buf *bytes.Buffer, s string) {
+	for _, r := range s {
+		switch r {
+		case '&':
+			buf.WriteString("&amp;")
+		case '<':
+			buf.WriteString("&lt;")
+		case '>':
+			buf.WriteString("&gt;")
+		case '"':
+			buf.WriteString("&quot;")
+		default:
+			buf.WriteRune(r)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamCellSuite struct{}
+
+var _ = Suite(&StreamCellSuite{})
+
+func (s *StreamCellSuite) TestWriteCellsRendersEachType(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A", "B", "C", "D", "E"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+
+	err = sf.WriteCells([]StreamCell{
+		NewStringStreamCell("hello", 0),
+		NewNumericStreamCell(3.5, 0),
+		NewBoolStreamCell(true, 0),
+		NewDateStreamCell(time.Unix(0, 0).UTC(), 0),
+		NewFormulaStreamCell("SUM(A1:A2)", 0),
+	})
+	t.Assert(err, IsNil)
+	t.Assert(sf.Close(), IsNil)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	t.Assert(err, IsNil)
+	var sheetXML string
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			t.Assert(err, IsNil)
+			data, err := io.ReadAll(rc)
+			t.Assert(err, IsNil)
+			rc.Close()
+			sheetXML = string(data)
+		}
+	}
+	t.Assert(sheetXML, Matches, `.*<c r="A2" s="0" t="inlineStr"><is><t>hello</t></is></c>.*`)
+	t.Assert(sheetXML, Matches, `.*<c r="B2" s="0"><v>3.5</v></c>.*`)
+	t.Assert(sheetXML, Matches, `.*<c r="C2" s="0" t="b"><v>1</v></c>.*`)
+	t.Assert(sheetXML, Matches, `.*<c r="E2" s="0"><f>SUM\(A1:A2\)</f></c>.*`)
+}
+
+func (s *StreamCellSuite) TestWriteCellsRejectsUnregisteredStyleID(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+
+	err = sf.WriteCells([]StreamCell{NewStringStreamCell("hello", 99)})
+	t.Assert(err, ErrorMatches, "xlsx: StreamCell StyleID 99 was never registered.*")
+}
+
+func (s *StreamCellSuite) TestWriteCellsErrorsBeforeNextSheet(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+
+	err = sf.WriteCells([]StreamCell{NewStringStreamCell("hello", 0)})
+	t.Assert(err, ErrorMatches, "xlsx: WriteCells called before NextSheet")
+}
@@ -0,0 +1,73 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"io"
+)
+
+// CompressionLevel selects the deflate trade-off used when zipping a
+// streamed workbook. It maps directly onto the levels compress/flate
+// exposes; Store skips compression entirely, which is often worth it for
+// write throughput when the output is about to be re-compressed anyway
+// (e.g. behind a gzip-compressing HTTP proxy).
+type CompressionLevel int
+
+const (
+	CompressionDefault CompressionLevel = iota
+	CompressionStore
+	CompressionBestSpeed
+	CompressionBestCompression
+)
+
+// SetCompressionLevel configures the deflate level used for every part
+// written into the workbook's zip archive. It must be called before Build.
+func (sb *StreamFileBuilder) SetCompressionLevel(level CompressionLevel) error {
+	if sb.built {
+		return BuiltStreamFileBuilderError
+	}
+	sb.compressionLevel = level
+	return nil
+}
+
+// registerCompressor wires sb.compressionLevel into zw via
+// zip.RegisterCompressor, so every subsequent zw.Create uses it. Every part
+// is still a Deflate entry; Store uses flate.NoCompression, which writes the
+// data as stored (uncompressed) deflate blocks.
+func registerCompressor(zw *zip.Writer, level CompressionLevel) {
+	switch level {
+	case CompressionStore:
+		zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(w, flate.NoCompression)
+		})
+	case CompressionBestSpeed:
+		zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(w, flate.BestSpeed)
+		})
+	case CompressionBestCompression:
+		zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(w, flate.BestCompression)
+		})
+	}
+	// CompressionDefault leaves archive/zip's own default compressor in place.
+}
+
+// storeWriteCloser adapts an io.Writer into the io.WriteCloser
+// zip.RegisterCompressor expects, passing bytes through uncompressed.
+type storeWriteCloser struct {
+	io.Writer
+}
+
+func (storeWriteCloser) Close() error { return nil }
+
+// forceZIP64 prepares a zip.FileHeader for a worksheet part whose final size
+// isn't known up front. Setting the data-descriptor bit means archive/zip
+// writes the part's sizes and CRC after its data rather than in the local
+// file header, which lets it transparently upgrade the part to ZIP64 at
+// Close time if it ends up larger than 4 GiB - exactly what a million-row,
+// wide-schema sheet can do. Without this, a sheet that crosses the limit
+// mid-write produces a corrupt archive instead of a valid ZIP64 one.
+func forceZIP64(fh *zip.FileHeader) {
+	fh.Flags |= 0x8
+	fh.Method = zip.Deflate
+}
@@ -0,0 +1,54 @@
+package xlsx
+
+import (
+	"bytes"
+	"testing"
+)
+
+// BenchmarkStreamFileCompressionLevels compares write throughput across the
+// available CompressionLevel settings. Row/column counts are kept modest so
+// the benchmark suite stays fast to run; scale rowCount up locally to
+// reproduce the 1M-row numbers referenced in the compression-level design
+// discussion.
+func BenchmarkStreamFileCompressionLevels(b *testing.B) {
+	levels := map[string]CompressionLevel{
+		"Store":           CompressionStore,
+		"BestSpeed":       CompressionBestSpeed,
+		"Default":         CompressionDefault,
+		"BestCompression": CompressionBestCompression,
+	}
+	const rowCount = 10000
+	header := []string{"Token", "Name", "Price", "SKU"}
+	row := []string{"123", "Taco", "300", "0000000123"}
+
+	for name, level := range levels {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				buf := &bytes.Buffer{}
+				builder := NewStreamFileBuilder(buf)
+				if err := builder.SetCompressionLevel(level); err != nil {
+					b.Fatal(err)
+				}
+				if err := builder.AddSheet("Sheet1", header, nil); err != nil {
+					b.Fatal(err)
+				}
+				sf, err := builder.Build()
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := sf.NextSheet(); err != nil {
+					b.Fatal(err)
+				}
+				for r := 0; r < rowCount; r++ {
+					if err := sf.Write(row); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err := sf.Close(); err != nil {
+					b.Fatal(err)
+				}
+				b.ReportMetric(float64(buf.Len()), "bytes/op-size")
+			}
+		})
+	}
+}
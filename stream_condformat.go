@@ -0,0 +1,174 @@
+package xlsx
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ConditionalFormatRule describes a single rule within a <conditionalFormatting>
+// block. Type selects which CF rule shape is emitted; the fields relevant to
+// that type should be set and the rest left zero.
+type ConditionalFormatRule struct {
+	Type     string // "cellIs", "colorScale", "dataBar", "iconSet", or "expression"
+	Operator string // e.g. "greaterThan", used by Type == "cellIs"
+	Formula  string // comparison value or, for Type == "expression", the formula itself
+	DxfID    int    // index into styles.xml's <dxfs>, used by cellIs/expression rules; the styles.xml this package writes has no <dxfs> entries
+	Priority int
+
+	ColorScale   []string // hex colors, low-to-high, used by Type == "colorScale"
+	DataBarColor string   // used by Type == "dataBar"
+	IconSet      string   // e.g. "3TrafficLights1", used by Type == "iconSet"
+}
+
+// ConditionalFormat is a <conditionalFormatting sqref="..."> block: one cell
+// range and the ordered rules that apply to it.
+type ConditionalFormat struct {
+	Sqref string
+	Rules []ConditionalFormatRule
+}
+
+// DataValidationRule describes a single <dataValidation> entry.
+type DataValidationRule struct {
+	Sqref        string
+	Type         string // "list", "whole", "decimal", "date", "textLength", or "custom"
+	Operator     string
+	Formula1     string
+	Formula2     string
+	AllowBlank   bool
+	ShowDropDown bool
+	ErrorTitle   string
+	ErrorMessage string
+}
+
+// streamFormattingRules accumulates the conditional-formatting and
+// data-validation entries declared for a sheet before any rows are streamed.
+// They're buffered here because the schema requires them to follow
+// <sheetData>, which the streaming writer can only close once all rows for
+// that sheet have been flushed.
+type streamFormattingRules struct {
+	conditionalFormats []ConditionalFormat
+	dataValidations    []DataValidationRule
+}
+
+// AddConditionalFormat registers a conditional-formatting block for sheetName,
+// to be flushed after that sheet's rows are written. Rules are applied in the
+// order added, matching how Excel evaluates overlapping rules.
+func (sb *StreamFileBuilder) AddConditionalFormat(sheetName string, cf ConditionalFormat) error {
+	if sb.built {
+		return BuiltStreamFileBuilderError
+	}
+	rules := sb.formattingRules[sheetName]
+	if rules == nil {
+		rules = &streamFormattingRules{}
+		if sb.formattingRules == nil {
+			sb.formattingRules = map[string]*streamFormattingRules{}
+		}
+		sb.formattingRules[sheetName] = rules
+	}
+	rules.conditionalFormats = append(rules.conditionalFormats, cf)
+	return nil
+}
+
+// AddDataValidation registers a data-validation entry for sheetName, to be
+// flushed after that sheet's rows (and any conditional formatting) are
+// written.
+func (sb *StreamFileBuilder) AddDataValidation(sheetName string, dv DataValidationRule) error {
+	if sb.built {
+		return BuiltStreamFileBuilderError
+	}
+	rules := sb.formattingRules[sheetName]
+	if rules == nil {
+		rules = &streamFormattingRules{}
+		if sb.formattingRules == nil {
+			sb.formattingRules = map[string]*streamFormattingRules{}
+		}
+		sb.formattingRules[sheetName] = rules
+	}
+	rules.dataValidations = append(rules.dataValidations, dv)
+	return nil
+}
+
+// writeFormattingRules renders the buffered conditional-formatting and
+// data-validation blocks for a sheet. It must run after </sheetData> and
+// </mergeCells> but before <hyperlinks>/<pageMargins>, per the schema's
+// fixed element order.
+func writeFormattingRules(buf *bytes.Buffer, rules *streamFormattingRules) {
+	if rules == nil {
+		return
+	}
+	for _, cf := range rules.conditionalFormats {
+		fmt.Fprintf(buf, `<conditionalFormatting sqref="%s">`, escapeXMLAttr(cf.Sqref))
+		for _, rule := range cf.Rules {
+			writeConditionalFormatRule(buf, rule)
+		}
+		buf.WriteString(`</conditionalFormatting>`)
+	}
+	if len(rules.dataValidations) > 0 {
+		fmt.Fprintf(buf, `<dataValidations count="%d">`, len(rules.dataValidations))
+		for _, dv := range rules.dataValidations {
+			writeDataValidationRule(buf, dv)
+		}
+		buf.WriteString(`</dataValidations>`)
+	}
+}
+
+func writeConditionalFormatRule(buf *bytes.Buffer, rule ConditionalFormatRule) {
+	switch rule.Type {
+	case "colorScale":
+		buf.WriteString(`<cfRule type="colorScale" priority="` + itoa(rule.Priority) + `"><colorScale>`)
+		for i, color := range rule.ColorScale {
+			fmt.Fprintf(buf, `<cfvo type="percentile" val="%d"/>`, i*100/maxInt(len(rule.ColorScale)-1, 1))
+			fmt.Fprintf(buf, `<color rgb="%s"/>`, escapeXMLAttr(color))
+		}
+		buf.WriteString(`</colorScale></cfRule>`)
+	case "dataBar":
+		fmt.Fprintf(buf, `<cfRule type="dataBar" priority="%d"><dataBar><cfvo type="min"/><cfvo type="max"/><color rgb="%s"/></dataBar></cfRule>`,
+			rule.Priority, escapeXMLAttr(rule.DataBarColor))
+	case "iconSet":
+		fmt.Fprintf(buf, `<cfRule type="iconSet" priority="%d"><iconSet iconSet="%s"/></cfRule>`, rule.Priority, escapeXMLAttr(rule.IconSet))
+	case "expression":
+		fmt.Fprintf(buf, `<cfRule type="expression" dxfId="%d" priority="%d"><formula>%s</formula></cfRule>`,
+			rule.DxfID, rule.Priority, escapeXMLAttr(rule.Formula))
+	default: // "cellIs"
+		fmt.Fprintf(buf, `<cfRule type="cellIs" dxfId="%d" priority="%d" operator="%s"><formula>%s</formula></cfRule>`,
+			rule.DxfID, rule.Priority, rule.Operator, escapeXMLAttr(rule.Formula))
+	}
+}
+
+func writeDataValidationRule(buf *bytes.Buffer, dv DataValidationRule) {
+	buf.WriteString(`<dataValidation`)
+	fmt.Fprintf(buf, ` type="%s"`, dv.Type)
+	if dv.Operator != "" {
+		fmt.Fprintf(buf, ` operator="%s"`, dv.Operator)
+	}
+	fmt.Fprintf(buf, ` allowBlank="%d"`, boolToInt(dv.AllowBlank))
+	if dv.Type == "list" {
+		fmt.Fprintf(buf, ` showDropDown="%d"`, boolToInt(!dv.ShowDropDown))
+	}
+	fmt.Fprintf(buf, ` sqref="%s">`, escapeXMLAttr(dv.Sqref))
+	if dv.Formula1 != "" {
+		fmt.Fprintf(buf, `<formula1>%s</formula1>`, escapeXMLAttr(dv.Formula1))
+	}
+	if dv.Formula2 != "" {
+		fmt.Fprintf(buf, `<formula2>%s</formula2>`, escapeXMLAttr(dv.Formula2))
+	}
+	buf.WriteString(`</dataValidation>`)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func itoa(i int) string {
+	return fmt.Sprintf("%d", i)
+}
@@ -0,0 +1,70 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamCondFormatSuite struct{}
+
+var _ = Suite(&StreamCondFormatSuite{})
+
+func (s *StreamCondFormatSuite) TestConditionalFormatAndDataValidationAreWritten(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Score"}, nil), IsNil)
+	t.Assert(builder.AddConditionalFormat("Sheet1", ConditionalFormat{
+		Sqref: "A2:A100",
+		Rules: []ConditionalFormatRule{
+			{Type: "cellIs", Operator: "greaterThan", Formula: "50", DxfID: 1, Priority: 1},
+		},
+	}), IsNil)
+	t.Assert(builder.AddDataValidation("Sheet1", DataValidationRule{
+		Sqref: "B2:B100", Type: "list", Formula1: `"Yes,No"`, AllowBlank: true,
+	}), IsNil)
+
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+	t.Assert(sf.Close(), IsNil)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	t.Assert(err, IsNil)
+	var sheetXML string
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			t.Assert(err, IsNil)
+			data, err := io.ReadAll(rc)
+			t.Assert(err, IsNil)
+			rc.Close()
+			sheetXML = string(data)
+		}
+	}
+	t.Assert(strings.Contains(sheetXML, `<conditionalFormatting sqref="A2:A100">`), Equals, true)
+	t.Assert(strings.Contains(sheetXML, `<dataValidations count="1">`), Equals, true)
+}
+
+func (s *StreamCondFormatSuite) TestFormattingRuleFieldsAreEscaped(t *C) {
+	buf := &bytes.Buffer{}
+	rules := &streamFormattingRules{
+		conditionalFormats: []ConditionalFormat{{
+			Sqref: `A1"><evil/>`,
+			Rules: []ConditionalFormatRule{
+				{Type: "expression", Formula: `A1<B1 & "x"`, Priority: 1},
+			},
+		}},
+		dataValidations: []DataValidationRule{{
+			Sqref: "B1", Type: "custom", Formula1: `A1<"x"`,
+		}},
+	}
+	writeFormattingRules(buf, rules)
+	out := buf.String()
+	t.Assert(strings.Contains(out, `"><evil/>`), Equals, false)
+	t.Assert(strings.Contains(out, "A1<B1"), Equals, false)
+	t.Assert(strings.Contains(out, "&lt;"), Equals, true)
+}
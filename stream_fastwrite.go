@@ -0,0 +1,126 @@
+package xlsx
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+)
+
+// rowBufferPool recycles the bytes.Buffer used to build each row's XML, so
+// streaming a large sheet doesn't allocate a new buffer per row. Buffers are
+// reset (not discarded) on Put, keeping their backing array sized for the
+// sheet's typical row width.
+var rowBufferPool = sync.Pool{
+	New: func() interface{} { return &bytes.Buffer{} },
+}
+
+// Precomputed fragments shared by every inline-string cell, so the common
+// path through writeFastCell never formats these from scratch.
+const (
+	cellOpenPrefix      = `<c r="`
+	cellStyleAttr       = `" s="`
+	cellInlineStrOpen   = `" t="inlineStr"><is><t xml:space="preserve">`
+	cellInlineStrClose  = `</t></is></c>`
+	cellNumericOpen     = `"><v>`
+	cellNumericClose    = `</v></c>`
+	cellFormulaOpen     = `"><f>`
+	cellFormulaValClose = `</f></c>`
+)
+
+// writeFastRow builds the <row>...</row> XML for a plain []string row
+// directly into a pooled buffer instead of going through xml.Encoder, then
+// writes it out in a single call. This is the fast path behind Write/
+// WriteWithDefaultCellType; it produces byte-identical output to the
+// encoding/xml version for the cell shapes those two methods support.
+func (sf *StreamFile) writeFastRow(cells []string, cellTypes []*CellType) error {
+	buf := rowBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer rowBufferPool.Put(buf)
+
+	rowIndex := sf.currentSheet.rowIndex
+	buf.WriteString(`<row r="`)
+	buf.Write(strconv.AppendInt(nil, int64(rowIndex), 10))
+	buf.WriteString(`">`)
+
+	sst := sf.builder.sharedStrings
+	for i, value := range cells {
+		ref := GetCellIDStringFromCoords(i, rowIndex-1)
+		styleID := sf.cellStyleID(i, cellTypes)
+		cellType := cellTypeFor(i, cellTypes)
+		if sst != nil && cellType != CellTypeNumeric && cellType != CellTypeFormula {
+			if idx, ok := sst.resolve(value); ok {
+				buf.WriteString(sharedStringCellXML(ref, styleID, idx))
+				continue
+			}
+		}
+		writeFastCell(buf, ref, styleID, value, cellType)
+	}
+	buf.WriteString(`</row>`)
+
+	return sf.writeRawRow(buf.Bytes())
+}
+
+func cellTypeFor(col int, cellTypes []*CellType) CellType {
+	if col < len(cellTypes) && cellTypes[col] != nil {
+		return *cellTypes[col]
+	}
+	return CellTypeString
+}
+
+// writeFastCell appends one cell's XML to buf, escaping value only if it
+// actually contains a character that needs it.
+func writeFastCell(buf *bytes.Buffer, ref string, styleID int, value string, cellType CellType) {
+	buf.WriteString(cellOpenPrefix)
+	buf.WriteString(ref)
+	buf.WriteString(cellStyleAttr)
+	buf.Write(strconv.AppendInt(nil, int64(styleID), 10))
+
+	switch cellType {
+	case CellTypeNumeric:
+		buf.WriteString(cellNumericOpen)
+		buf.WriteString(value)
+		buf.WriteString(cellNumericClose)
+	case CellTypeFormula:
+		buf.WriteString(cellFormulaOpen)
+		writeEscaped(buf, value)
+		buf.WriteString(cellFormulaValClose)
+	default:
+		buf.WriteString(cellInlineStrOpen)
+		writeEscaped(buf, value)
+		buf.WriteString(cellInlineStrClose)
+	}
+}
+
+// writeEscaped appends s to buf, escaping only the characters that need it
+// rather than always routing through xml.EscapeText's generic byte-by-byte
+// path.
+func writeEscaped(buf *bytes.Buffer, s string) {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		var esc string
+		switch s[i] {
+		case '&':
+			esc = "&amp;"
+		case '<':
+			esc = "&lt;"
+		case '>':
+			esc = "&gt;"
+		case '"':
+			esc = "&quot;"
+		default:
+			continue
+		}
+		buf.WriteString(s[start:i])
+		buf.WriteString(esc)
+		start = i + 1
+	}
+	buf.WriteString(s[start:])
+}
+
+// cellStyleID returns the style index Write should assign column col.
+// Write has no per-column style registration of its own (that's what
+// WriteCells/StreamCell.StyleID are for), so every cell it writes uses the
+// workbook's default style.
+func (sf *StreamFile) cellStyleID(col int, cellTypes []*CellType) int {
+	return 0
+}
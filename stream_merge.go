@@ -0,0 +1,51 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MergeCell declares that the rectangular range from hCell to vCell should
+// be merged once the current sheet is closed. Like MergeCells, it may be
+// called while rows are being written; unlike MergeCells, which only checks
+// the top row, it validates both endpoints against the rows already flushed
+// and the sheet's declared column count.
+func (sf *StreamFile) MergeCell(hCell, vCell string) error {
+	if sf.currentSheet == nil {
+		return errors.New("xlsx: MergeCell called before NextSheet")
+	}
+	hCol, hRow, err := GetCoordsFromCellIDString(hCell)
+	if err != nil {
+		return err
+	}
+	vCol, vRow, err := GetCoordsFromCellIDString(vCell)
+	if err != nil {
+		return err
+	}
+
+	maxCol := len(sf.currentSheet.headers) - 1
+	if hCol > maxCol || vCol > maxCol {
+		return fmt.Errorf("xlsx: merge %s:%s references column beyond the sheet's %d declared columns", hCell, vCell, maxCol+1)
+	}
+	if hRow < sf.currentSheet.maxRowWritten-1 || vRow < sf.currentSheet.maxRowWritten-1 {
+		return fmt.Errorf("xlsx: merge %s:%s references a row already flushed", hCell, vCell)
+	}
+
+	return sf.MergeCells(hCell, vCell)
+}
+
+// WriteMergedRow writes row exactly as Write would, then merges its cells
+// from startCol to endCol (0-indexed, inclusive) into a single cell -- a
+// common need for report title/section header rows.
+func (sf *StreamFile) WriteMergedRow(row []string, startCol, endCol int) error {
+	if sf.currentSheet == nil {
+		return errors.New("xlsx: WriteMergedRow called before NextSheet")
+	}
+	rowIndex := sf.currentSheet.rowIndex
+	if err := sf.Write(row); err != nil {
+		return err
+	}
+	topLeft := GetCellIDStringFromCoords(startCol, rowIndex-1)
+	bottomRight := GetCellIDStringFromCoords(endCol, rowIndex-1)
+	return sf.MergeCell(topLeft, bottomRight)
+}
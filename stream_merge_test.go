@@ -0,0 +1,71 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamMergeSuite struct{}
+
+var _ = Suite(&StreamMergeSuite{})
+
+func (s *StreamMergeSuite) TestWriteMergedRow(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Title", "", ""}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+
+	t.Assert(sf.WriteMergedRow([]string{"Report Title", "", ""}, 0, 2), IsNil)
+	t.Assert(sf.Close(), IsNil)
+
+	sheetXML := readZipEntry(t, buf, "xl/worksheets/sheet1.xml")
+	t.Assert(strings.Contains(sheetXML, `<mergeCell ref="A2:C2"/>`), Equals, true)
+}
+
+func (s *StreamMergeSuite) TestMergeCellRejectsColumnBeyondDeclaredWidth(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A", "B"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+
+	err = sf.MergeCell("A3", "C3")
+	t.Assert(err, ErrorMatches, "xlsx: merge .* references column beyond the sheet's 2 declared columns")
+}
+
+func (s *StreamMergeSuite) TestMergeCellRejectsRowAlreadyFlushed(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)            // writes header, row 1
+	t.Assert(sf.Write([]string{"123"}), IsNil) // writes row 2
+
+	// Row 1 is no longer the most recently written row, so it can't be
+	// merged into anymore.
+	err = sf.MergeCell("A1", "A1")
+	t.Assert(err, ErrorMatches, "xlsx: merge .* references a row already flushed")
+
+	// Row 2, the one just written, can still be merged.
+	t.Assert(sf.MergeCell("A2", "A2"), IsNil)
+}
+
+func (s *StreamMergeSuite) TestMergeCellAndWriteMergedRowErrorBeforeNextSheet(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+
+	err = sf.MergeCell("A1", "A1")
+	t.Assert(err, ErrorMatches, "xlsx: MergeCell called before NextSheet")
+
+	err = sf.WriteMergedRow([]string{"x"}, 0, 0)
+	t.Assert(err, ErrorMatches, "xlsx: WriteMergedRow called before NextSheet")
+}
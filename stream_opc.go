@@ -0,0 +1,169 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// OPC (Open Packaging Conventions) relationship type and content type
+// strings this package needs beyond the ones already declared alongside
+// the parts they describe (sharedStringsRelType/sharedStringsContentTypeOverride
+// in stream_sharedstrings.go, dynamicArrayRelType/dynamicArrayContentType in
+// stream_arrayformula.go, tableContentTypeOverride in stream_table.go).
+const (
+	relationshipsContentType = "application/vnd.openxmlformats-package.relationships+xml"
+	xmlContentType           = "application/xml"
+	workbookContentType      = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
+	worksheetContentType     = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
+	stylesContentType        = "application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"
+
+	officeDocumentRelType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	worksheetRelType      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+	stylesRelType         = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
+	tableRelType          = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+)
+
+// writePackageParts writes the OPC scaffolding every XLSX needs regardless
+// of which optional features a workbook uses: the package-level
+// [Content_Types].xml and _rels/.rels, and xl/workbook.xml plus its
+// xl/_rels/workbook.xml.rels, which is where a sheet's name/order and its
+// worksheet part are tied together via r:id. It's called once, by
+// StreamFile.Close, after every other part (styles, shared strings,
+// dynamic-array metadata, tables) has been written, so it can see the
+// builder state those parts depend on.
+func writePackageParts(zw *zip.Writer, sb *StreamFileBuilder) error {
+	if err := writeRootRelsPart(zw); err != nil {
+		return err
+	}
+	if err := writeWorkbookPart(zw, sb); err != nil {
+		return err
+	}
+	if err := writeWorkbookRelsPart(zw, sb); err != nil {
+		return err
+	}
+	return writeContentTypesPart(zw, sb)
+}
+
+// writeRootRelsPart writes _rels/.rels, the package's single entry point:
+// the relationship from the package root to xl/workbook.xml.
+func writeRootRelsPart(zw *zip.Writer) error {
+	w, err := zw.Create("_rels/.rels")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`+
+		`<Relationship Id="rId1" Type="`+officeDocumentRelType+`" Target="xl/workbook.xml"/>`+
+		`</Relationships>`)
+	return err
+}
+
+// writeWorkbookPart writes xl/workbook.xml, declaring every sheet the
+// builder collected via AddSheet/AddSheetS in order, each pointing at its
+// worksheet part through the r:id assigned in writeWorkbookRelsPart.
+func writeWorkbookPart(zw *zip.Writer, sb *StreamFileBuilder) error {
+	w, err := zw.Create("xl/workbook.xml")
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	buf.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	buf.WriteString(`<sheets>`)
+	for i, name := range sb.sheetNames {
+		fmt.Fprintf(buf, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXMLAttr(name), i+1, i+1)
+	}
+	buf.WriteString(`</sheets>`)
+	buf.WriteString(`</workbook>`)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeWorkbookRelsPart writes xl/_rels/workbook.xml.rels: one relationship
+// per worksheet (rId1..rIdN, matching writeWorkbookPart's r:id values) plus
+// the workbook-level parts that aren't referenced by r:id from anywhere
+// else in the XML -- styles, and shared strings/dynamic-array metadata when
+// the builder used them.
+func writeWorkbookRelsPart(zw *zip.Writer, sb *StreamFileBuilder) error {
+	w, err := zw.Create("xl/_rels/workbook.xml.rels")
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	buf.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	rID := 1
+	for i := range sb.sheetNames {
+		fmt.Fprintf(buf, `<Relationship Id="rId%d" Type="%s" Target="worksheets/sheet%d.xml"/>`, rID, worksheetRelType, i+1)
+		rID++
+	}
+	fmt.Fprintf(buf, `<Relationship Id="rId%d" Type="%s" Target="styles.xml"/>`, rID, stylesRelType)
+	rID++
+	if sb.sharedStrings != nil {
+		fmt.Fprintf(buf, `<Relationship Id="rId%d" Type="%s" Target="sharedStrings.xml"/>`, rID, sharedStringsRelType)
+		rID++
+	}
+	if sb.usesDynamicArrays {
+		fmt.Fprintf(buf, `<Relationship Id="rId%d" Type="%s" Target="metadata.xml"/>`, rID, dynamicArrayRelType)
+		rID++
+	}
+	buf.WriteString(`</Relationships>`)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeContentTypesPart writes [Content_Types].xml, declaring the content
+// type of every part this package can emit: the Defaults every OPC package
+// needs for bare ".rels"/".xml" extensions, then an Override for each part
+// whose content type isn't implied by its extension alone.
+func writeContentTypesPart(zw *zip.Writer, sb *StreamFileBuilder) error {
+	w, err := zw.Create("[Content_Types].xml")
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	buf.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	fmt.Fprintf(buf, `<Default Extension="rels" ContentType="%s"/>`, relationshipsContentType)
+	fmt.Fprintf(buf, `<Default Extension="xml" ContentType="%s"/>`, xmlContentType)
+	fmt.Fprintf(buf, `<Override PartName="/xl/workbook.xml" ContentType="%s"/>`, workbookContentType)
+	for i := range sb.sheetNames {
+		fmt.Fprintf(buf, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="%s"/>`, i+1, worksheetContentType)
+	}
+	fmt.Fprintf(buf, `<Override PartName="/xl/styles.xml" ContentType="%s"/>`, stylesContentType)
+	if sb.sharedStrings != nil {
+		buf.WriteString(sharedStringsContentTypeOverride)
+	}
+	if sb.usesDynamicArrays {
+		fmt.Fprintf(buf, `<Override PartName="/xl/metadata.xml" ContentType="%s"/>`, dynamicArrayContentType)
+	}
+	for i, name := range sb.sheetNames {
+		if sb.tables[name] != nil {
+			buf.WriteString(tableContentTypeOverride(fmt.Sprintf("xl/tables/table%d.xml", i+1)))
+		}
+	}
+	buf.WriteString(`</Types>`)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeSheetRelsPart writes xl/worksheets/_rels/sheetN.xml.rels, the part
+// that resolves a worksheet's own r:id references -- currently just its
+// tablePart, if AddTable was declared for that sheet -- to their targets.
+// Unlike workbook.xml.rels, this lives alongside the part it describes
+// because table r:ids are scoped to the worksheet, not the workbook.
+func writeSheetRelsPart(zw *zip.Writer, sheetIndex int, relID string) error {
+	w, err := zw.Create(fmt.Sprintf("xl/worksheets/_rels/sheet%d.xml.rels", sheetIndex+1))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`+
+		`<Relationship Id="%s" Type="%s" Target="../tables/table%d.xml"/>`+
+		`</Relationships>`,
+		relID, tableRelType, sheetIndex+1)
+	return err
+}
@@ -0,0 +1,144 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamOPCSuite struct{}
+
+var _ = Suite(&StreamOPCSuite{})
+
+// xlsxRelationships and xlsxWorkbook are just enough of the OPC/workbook
+// schemas to round-trip-decode what this package writes, rather than
+// string-matching the XML the way the other stream_*_test.go files do.
+type xlsxRelationships struct {
+	XMLName       xml.Name `xml:"Relationships"`
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorkbook struct {
+	XMLName xml.Name `xml:"workbook"`
+	Sheets  struct {
+		Sheet []struct {
+			Name    string `xml:"name,attr"`
+			SheetID string `xml:"sheetId,attr"`
+			RID     string `xml:"id,attr"`
+		} `xml:"sheet"`
+	} `xml:"sheets"`
+}
+
+type xlsxContentTypes struct {
+	XMLName  xml.Name `xml:"Types"`
+	Defaults []struct {
+		Extension   string `xml:"Extension,attr"`
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Default"`
+	Overrides []struct {
+		PartName    string `xml:"PartName,attr"`
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Override"`
+}
+
+func (s *StreamOPCSuite) TestBuiltPackageIsValidOPC(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A", "B"}, nil), IsNil)
+	t.Assert(builder.AddSheet("Sheet2", []string{"C"}, nil), IsNil)
+	t.Assert(builder.UseSharedStrings(0), IsNil)
+
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+	t.Assert(sf.Write([]string{"1", "2"}), IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+	t.Assert(sf.Write([]string{"3"}), IsNil)
+	t.Assert(sf.Close(), IsNil)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	t.Assert(err, IsNil)
+
+	byName := map[string]*zip.File{}
+	for _, f := range zr.File {
+		byName[f.Name] = f
+	}
+
+	for _, part := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/worksheets/sheet1.xml",
+		"xl/worksheets/sheet2.xml",
+		"xl/styles.xml",
+		"xl/sharedStrings.xml",
+	} {
+		t.Assert(byName[part], NotNil, Commentf("missing required OPC part %q", part))
+	}
+
+	// _rels/.rels must point at the workbook part.
+	var rootRels xlsxRelationships
+	decodePart(t, byName["_rels/.rels"], &rootRels)
+	t.Assert(rootRels.Relationships, HasLen, 1)
+	t.Assert(rootRels.Relationships[0].Target, Equals, "xl/workbook.xml")
+
+	// xl/workbook.xml must declare both sheets, in order, each with an
+	// r:id that resolves to a worksheet relationship in workbook.xml.rels.
+	var wb xlsxWorkbook
+	decodePart(t, byName["xl/workbook.xml"], &wb)
+	t.Assert(wb.Sheets.Sheet, HasLen, 2)
+	t.Assert(wb.Sheets.Sheet[0].Name, Equals, "Sheet1")
+	t.Assert(wb.Sheets.Sheet[1].Name, Equals, "Sheet2")
+
+	var wbRels xlsxRelationships
+	decodePart(t, byName["xl/_rels/workbook.xml.rels"], &wbRels)
+	targetByID := map[string]string{}
+	for _, rel := range wbRels.Relationships {
+		targetByID[rel.ID] = rel.Target
+	}
+	t.Assert(targetByID[wb.Sheets.Sheet[0].RID], Equals, "worksheets/sheet1.xml")
+	t.Assert(targetByID[wb.Sheets.Sheet[1].RID], Equals, "worksheets/sheet2.xml")
+
+	// [Content_Types].xml must cover every part actually present.
+	var ct xlsxContentTypes
+	decodePart(t, byName["[Content_Types].xml"], &ct)
+	overridden := map[string]bool{}
+	for _, o := range ct.Overrides {
+		overridden[o.PartName] = true
+	}
+	t.Assert(overridden["/xl/workbook.xml"], Equals, true)
+	t.Assert(overridden["/xl/worksheets/sheet1.xml"], Equals, true)
+	t.Assert(overridden["/xl/worksheets/sheet2.xml"], Equals, true)
+	t.Assert(overridden["/xl/styles.xml"], Equals, true)
+
+	// Every XML part must at least be well-formed, not just string-matched.
+	for name, f := range byName {
+		rc, err := f.Open()
+		t.Assert(err, IsNil)
+		dec := xml.NewDecoder(rc)
+		var tokenErr error
+		for {
+			if _, tokenErr = dec.Token(); tokenErr != nil {
+				break
+			}
+		}
+		t.Assert(tokenErr, Equals, io.EOF, Commentf("part %q is not well-formed XML", name))
+		rc.Close()
+	}
+}
+
+func decodePart(t *C, f *zip.File, v interface{}) {
+	t.Assert(f, NotNil)
+	rc, err := f.Open()
+	t.Assert(err, IsNil)
+	defer rc.Close()
+	t.Assert(xml.NewDecoder(rc).Decode(v), IsNil)
+}
@@ -0,0 +1,239 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// StreamFileReader reads the worksheets of an XLSX file one row at a time,
+// without ever materializing a full sheet (or the workbook) in memory. It is
+// the read-side counterpart to StreamFileBuilder/StreamFile: where the
+// writer streams rows out to a zip archive, the reader streams them back in
+// from one.
+//
+// Shared strings are not loaded into memory up front. Instead, the reader
+// builds an index of byte offsets into the zip's xl/sharedStrings.xml entry
+// (one offset per <si>) and re-reads just the requested string's bytes from
+// the archive on demand, so memory holds one offset per unique string rather
+// than the strings themselves.
+type StreamFileReader struct {
+	zipReader *zip.Reader
+	sheets    []*zip.File
+	sheetIdx  int
+
+	sstFile    *zip.File
+	sstOffsets []int64 // byte offset of each <si> within the decompressed sst part
+
+	dec     *xml.Decoder
+	rc      io.ReadCloser
+	row     []string
+	rowSeen bool
+}
+
+// NewStreamFileReader opens the XLSX file backed by ra (size bytes long) and
+// returns a StreamFileReader positioned before the first sheet. Callers must
+// call NextSheet before NextRow.
+func NewStreamFileReader(ra io.ReaderAt, size int64) (*StreamFileReader, error) {
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &StreamFileReader{zipReader: zr, sheetIdx: -1}
+	for _, f := range zr.File {
+		if isSheetXMLName(f.Name) {
+			r.sheets = append(r.sheets, f)
+		}
+		if f.Name == "xl/sharedStrings.xml" {
+			r.sstFile = f
+		}
+	}
+	if r.sstFile != nil {
+		if err := r.indexSharedStrings(); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func isSheetXMLName(name string) bool {
+	return len(name) > len("xl/worksheets/sheet") &&
+		name[:len("xl/worksheets/sheet")] == "xl/worksheets/sheet"
+}
+
+// indexSharedStrings walks xl/sharedStrings.xml once, recording the start
+// offset of every <si> element so individual strings can be re-read later
+// without holding the whole table in memory.
+func (r *StreamFileReader) indexSharedStrings() error {
+	rc, err := r.sstFile.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	dec := xml.NewDecoder(rc)
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "si" {
+			r.sstOffsets = append(r.sstOffsets, start)
+		}
+	}
+	return nil
+}
+
+// sharedString resolves the shared string at idx by re-opening the sst part
+// and re-decoding just the single <si> at the recorded offset.
+func (r *StreamFileReader) sharedString(idx int) (string, error) {
+	if idx < 0 || idx >= len(r.sstOffsets) {
+		return "", fmt.Errorf("xlsx: shared string index %d out of range", idx)
+	}
+	rc, err := r.sstFile.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	if _, err := io.CopyN(io.Discard, rc, r.sstOffsets[idx]); err != nil {
+		return "", err
+	}
+	dec := xml.NewDecoder(rc)
+	var sst struct {
+		T string `xml:"t"`
+		R []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	}
+	if err := dec.Decode(&sst); err != nil {
+		return "", err
+	}
+	if sst.T != "" || len(sst.R) == 0 {
+		return sst.T, nil
+	}
+	var b []byte
+	for _, run := range sst.R {
+		b = append(b, run.T...)
+	}
+	return string(b), nil
+}
+
+// NextSheet advances to the next worksheet in the workbook, returning false
+// once the sheets are exhausted.
+func (r *StreamFileReader) NextSheet() (bool, error) {
+	if r.rc != nil {
+		r.rc.Close()
+		r.rc = nil
+		r.dec = nil
+	}
+	r.sheetIdx++
+	if r.sheetIdx >= len(r.sheets) {
+		return false, nil
+	}
+	rc, err := r.sheets[r.sheetIdx].Open()
+	if err != nil {
+		return false, err
+	}
+	r.rc = rc
+	r.dec = xml.NewDecoder(rc)
+	return true, nil
+}
+
+// xlsxStreamCell mirrors just enough of the <c> element to decode a cell
+// without pulling in the full (in-memory) xlsxC type.
+type xlsxStreamCell struct {
+	R  string `xml:"r,attr"`
+	T  string `xml:"t,attr"`
+	V  string `xml:"v"`
+	Is struct {
+		T string `xml:"t"`
+	} `xml:"is"`
+}
+
+// NextRow advances the decoder to the next <row> in the current sheet and
+// resolves its cell values, returning false once the sheet is exhausted.
+// Call Cells to retrieve the values found.
+func (r *StreamFileReader) NextRow() (bool, error) {
+	if r.dec == nil {
+		return false, fmt.Errorf("xlsx: NextRow called before NextSheet")
+	}
+	for {
+		tok, err := r.dec.Token()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "row" {
+			continue
+		}
+		var row struct {
+			C []xlsxStreamCell `xml:"c"`
+		}
+		if err := r.dec.DecodeElement(&row, &se); err != nil {
+			return false, err
+		}
+		cols := make([]int, len(row.C))
+		width := 0
+		for i, c := range row.C {
+			col, _, err := GetCoordsFromCellIDString(c.R)
+			if err != nil {
+				return false, err
+			}
+			cols[i] = col
+			if col+1 > width {
+				width = col + 1
+			}
+		}
+		cells := make([]string, width)
+		for i, c := range row.C {
+			col := cols[i]
+			switch c.T {
+			case "s":
+				if c.V == "" {
+					continue
+				}
+				idx, err := strconv.Atoi(c.V)
+				if err != nil {
+					return false, err
+				}
+				s, err := r.sharedString(idx)
+				if err != nil {
+					return false, err
+				}
+				cells[col] = s
+			case "inlineStr":
+				cells[col] = c.Is.T
+			default:
+				cells[col] = c.V
+			}
+		}
+		r.row = cells
+		r.rowSeen = true
+		return true, nil
+	}
+}
+
+// Cells returns the cell values of the row most recently returned by
+// NextRow.
+func (r *StreamFileReader) Cells() []string {
+	return r.row
+}
+
+// Close releases the resources held by the reader's current sheet.
+func (r *StreamFileReader) Close() error {
+	if r.rc != nil {
+		return r.rc.Close()
+	}
+	return nil
+}
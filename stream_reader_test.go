@@ -0,0 +1,69 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamReaderSuite struct{}
+
+var _ = Suite(&StreamReaderSuite{})
+
+// buildTestWorkbookZip assembles a minimal in-memory zip with one worksheet
+// (mixing an inline string cell and a shared-string cell) and a matching
+// sharedStrings part, just enough to exercise StreamFileReader.
+func buildTestWorkbookZip(c *C) *bytes.Reader {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+
+	sheetW, err := zw.Create("xl/worksheets/sheet1.xml")
+	c.Assert(err, IsNil)
+	_, err = sheetW.Write([]byte(`<?xml version="1.0"?>` +
+		`<worksheet><sheetData>` +
+		`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="inlineStr"><is><t>Inline</t></is></c></row>` +
+		`<row r="2"><c r="A2" t="s"><v>1</v></c><c r="B2"><v>42</v></c></row>` +
+		`</sheetData></worksheet>`))
+	c.Assert(err, IsNil)
+
+	sstW, err := zw.Create("xl/sharedStrings.xml")
+	c.Assert(err, IsNil)
+	_, err = sstW.Write([]byte(`<?xml version="1.0"?>` +
+		`<sst count="2" uniqueCount="2">` +
+		`<si><t>Token</t></si>` +
+		`<si><t>Price</t></si>` +
+		`</sst>`))
+	c.Assert(err, IsNil)
+
+	c.Assert(zw.Close(), IsNil)
+	return bytes.NewReader(buf.Bytes())
+}
+
+func (s *StreamReaderSuite) TestStreamFileReaderReadsRows(t *C) {
+	data := buildTestWorkbookZip(t)
+	r, err := NewStreamFileReader(data, int64(data.Len()))
+	t.Assert(err, IsNil)
+
+	hasSheet, err := r.NextSheet()
+	t.Assert(err, IsNil)
+	t.Assert(hasSheet, Equals, true)
+
+	hasRow, err := r.NextRow()
+	t.Assert(err, IsNil)
+	t.Assert(hasRow, Equals, true)
+	t.Assert(r.Cells(), DeepEquals, []string{"Token", "Inline"})
+
+	hasRow, err = r.NextRow()
+	t.Assert(err, IsNil)
+	t.Assert(hasRow, Equals, true)
+	t.Assert(r.Cells(), DeepEquals, []string{"Price", "42"})
+
+	hasRow, err = r.NextRow()
+	t.Assert(err, IsNil)
+	t.Assert(hasRow, Equals, false)
+
+	hasSheet, err = r.NextSheet()
+	t.Assert(err, IsNil)
+	t.Assert(hasSheet, Equals, false)
+}
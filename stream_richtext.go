@@ -0,0 +1,130 @@
+package xlsx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RichTextRun is one styled run within a RichText cell value. A run with no
+// styling set at all still renders as plain text inside the inline string.
+type RichTextRun struct {
+	Text      string
+	Font      string
+	Size      float64
+	Color     string // RGB or ARGB hex, e.g. "FF0000" or "FFFF0000"
+	Bold      bool
+	Italic    bool
+	Underline bool
+	Strike    bool
+}
+
+// RichText is a cell value made up of one or more differently-styled runs.
+// Passing a RichText to StreamFile.WriteRichRow serializes it as an inline
+// string (<is>) with one <r> per run, rather than the plain <t> used for
+// ordinary string cells.
+type RichText []RichTextRun
+
+// WriteRichRow writes a row in which any cell value may be a plain string
+// (written as an inline string with no style attribute, never through the
+// shared-string table), a RichText (serialized as a sequence
+// of styled inline-string runs), or an ArrayFormulaCell (a dynamic-array
+// formula).
+func (sf *StreamFile) WriteRichRow(cells []interface{}) error {
+	if sf.currentSheet == nil {
+		return errors.New("xlsx: WriteRichRow called before NextSheet")
+	}
+	rowIndex := sf.currentSheet.rowIndex
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<row r="%d">`, rowIndex)
+	for i, cell := range cells {
+		ref := GetCellIDStringFromCoords(i, rowIndex-1)
+		switch v := cell.(type) {
+		case RichText:
+			is, err := marshalInlineString(v)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(buf, `<c r="%s" t="inlineStr">%s</c>`, ref, is)
+		case string:
+			fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t`, ref)
+			if needsXMLSpacePreserve(v) {
+				buf.WriteString(` xml:space="preserve"`)
+			}
+			buf.WriteString(`>`)
+			xmlEscapeTo(buf, v)
+			buf.WriteString(`</t></is></c>`)
+		case ArrayFormulaCell:
+			sf.builder.registerDynamicArrayUsage()
+			buf.WriteString(v.xlsxCellXML(ref, 0))
+		default:
+			return fmt.Errorf("xlsx: WriteRichRow only accepts string, RichText, or ArrayFormulaCell cells, got %T", v)
+		}
+	}
+	buf.WriteString(`</row>`)
+	return sf.writeRawRow(buf.Bytes())
+}
+
+// normalizeRGB pads a 6-digit RGB color to the 8-digit ARGB form the XLSX
+// schema expects, defaulting alpha to fully opaque.
+func normalizeRGB(color string) string {
+	color = strings.ToUpper(strings.TrimPrefix(color, "#"))
+	if len(color) == 6 {
+		return "FF" + color
+	}
+	return color
+}
+
+func needsXMLSpacePreserve(s string) bool {
+	return len(s) > 0 && (s[0] == ' ' || s[len(s)-1] == ' ' || s[0] == '\t' || s[len(s)-1] == '\t')
+}
+
+// marshalInlineString renders a RichText's runs as the literal XML bytes
+// that belong inside a <c t="inlineStr">'s <is> element. It's used directly
+// by WriteRichRow's hand-rolled cell XML rather than going through
+// encoding/xml, matching the rest of the streaming writer's fast-path style.
+func marshalInlineString(rt RichText) (string, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("<is>")
+	for _, run := range rt {
+		buf.WriteString("<r>")
+		if run.Font != "" || run.Size != 0 || run.Color != "" || run.Bold || run.Italic || run.Underline || run.Strike {
+			buf.WriteString("<rPr>")
+			if run.Bold {
+				buf.WriteString("<b/>")
+			}
+			if run.Italic {
+				buf.WriteString("<i/>")
+			}
+			if run.Underline {
+				buf.WriteString(`<u val="single"/>`)
+			}
+			if run.Strike {
+				buf.WriteString("<strike/>")
+			}
+			if run.Color != "" {
+				fmt.Fprintf(buf, `<color rgb="%s"/>`, normalizeRGB(run.Color))
+			}
+			if run.Font != "" {
+				fmt.Fprintf(buf, `<rFont val="%s"/>`, escapeXMLAttr(run.Font))
+			}
+			if run.Size != 0 {
+				fmt.Fprintf(buf, `<sz val="%g"/>`, run.Size)
+			}
+			buf.WriteString("</rPr>")
+		}
+		buf.WriteString("<t")
+		if needsXMLSpacePreserve(run.Text) {
+			buf.WriteString(` xml:space="preserve"`)
+		}
+		buf.WriteString(">")
+		if err := xml.EscapeText(buf, []byte(run.Text)); err != nil {
+			return "", err
+		}
+		buf.WriteString("</t></r>")
+	}
+	buf.WriteString("</is>")
+	return buf.String(), nil
+}
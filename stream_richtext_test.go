@@ -0,0 +1,63 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamRichTextSuite struct{}
+
+var _ = Suite(&StreamRichTextSuite{})
+
+func (s *StreamRichTextSuite) TestWriteRichRowMixedCells(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Label", "Note"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+
+	err = sf.WriteRichRow([]interface{}{
+		"plain",
+		RichText{
+			{Text: "bold red ", Bold: true, Color: "FF0000"},
+			{Text: "plain run"},
+		},
+	})
+	t.Assert(err, IsNil)
+	t.Assert(sf.Close(), IsNil)
+}
+
+func (s *StreamRichTextSuite) TestWriteRichRowRejectsUnknownType(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Label"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+
+	err = sf.WriteRichRow([]interface{}{42})
+	t.Assert(err, ErrorMatches, "xlsx: WriteRichRow only accepts.*")
+}
+
+func (s *StreamRichTextSuite) TestWriteRichRowErrorsBeforeNextSheet(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Label"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+
+	err = sf.WriteRichRow([]interface{}{"plain"})
+	t.Assert(err, ErrorMatches, "xlsx: WriteRichRow called before NextSheet")
+}
+
+func (s *StreamRichTextSuite) TestMarshalInlineStringEscapesAttributes(t *C) {
+	is, err := marshalInlineString(RichText{
+		{Text: "hello", Font: `Arial"><script>`},
+	})
+	t.Assert(err, IsNil)
+	t.Assert(strings.Contains(is, `<script>`), Equals, false)
+	t.Assert(strings.Contains(is, "&quot;"), Equals, true)
+}
@@ -0,0 +1,92 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+)
+
+// UseSharedStrings opts the builder into routing string cells through a
+// bounded shared-string table (xl/sharedStrings.xml) instead of always
+// writing CellTypeInline strings. Once more than maxUniqueEntries distinct
+// strings have been seen, further new strings spill back to inline so
+// memory stays bounded regardless of how many unique values a report
+// contains; previously-seen strings keep reusing their shared-string index.
+// A maxUniqueEntries of 0 or less leaves the table unbounded.
+func (sb *StreamFileBuilder) UseSharedStrings(maxUniqueEntries int) error {
+	if sb.built {
+		return BuiltStreamFileBuilderError
+	}
+	sb.sharedStrings = &streamSharedStrings{maxUnique: maxUniqueEntries}
+	return nil
+}
+
+// streamSharedStrings is the builder-owned shared-string table for a
+// workbook streamed with UseSharedStrings enabled.
+type streamSharedStrings struct {
+	maxUnique int
+	index     map[string]int
+	values    []string
+	count     int // total string cells seen, including repeats
+}
+
+// resolve returns the shared-string index for s and true, or false if s
+// should instead be written inline because the table is full.
+func (sst *streamSharedStrings) resolve(s string) (int, bool) {
+	sst.count++
+	if sst.index == nil {
+		sst.index = map[string]int{}
+	}
+	if idx, ok := sst.index[s]; ok {
+		return idx, true
+	}
+	if sst.maxUnique > 0 && len(sst.values) >= sst.maxUnique {
+		return 0, false
+	}
+	idx := len(sst.values)
+	sst.values = append(sst.values, s)
+	sst.index[s] = idx
+	return idx, true
+}
+
+// sharedStringCellXML renders a shared-string cell, t="s" with a numeric <v>
+// pointing into xl/sharedStrings.xml.
+func sharedStringCellXML(ref string, styleID, sstIndex int) string {
+	return fmt.Sprintf(`<c r="%s" s="%d" t="s"><v>%d</v></c>`, ref, styleID, sstIndex)
+}
+
+// sharedStringsXML serializes the collected table into xl/sharedStrings.xml,
+// with the count/uniqueCount attributes the schema requires.
+func (sst *streamSharedStrings) sharedStringsXML() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+
+		`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="%d" uniqueCount="%d">`,
+		sst.count, len(sst.values))
+	for _, v := range sst.values {
+		buf.WriteString(`<si><t`)
+		if needsXMLSpacePreserve(v) {
+			buf.WriteString(` xml:space="preserve"`)
+		}
+		buf.WriteString(`>`)
+		xmlEscapeTo(buf, v)
+		buf.WriteString(`</t></si>`)
+	}
+	buf.WriteString(`</sst>`)
+	return buf.String()
+}
+
+const sharedStringsContentTypeOverride = `<Override PartName="/xl/sharedStrings.xml" ` +
+	`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"/>`
+
+const sharedStringsRelType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+
+// writeSharedStringsPart writes xl/sharedStrings.xml into the workbook zip
+// during StreamFile.Close, if the builder opted into UseSharedStrings.
+func writeSharedStringsPart(zw *zip.Writer, sst *streamSharedStrings) error {
+	w, err := zw.Create("xl/sharedStrings.xml")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(sst.sharedStringsXML()))
+	return err
+}
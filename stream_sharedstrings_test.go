@@ -0,0 +1,79 @@
+package xlsx
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamSharedStringsSuite struct{}
+
+var _ = Suite(&StreamSharedStringsSuite{})
+
+func (s *StreamSharedStringsSuite) TestSharedStringsPreservesSurroundingWhitespace(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.UseSharedStrings(0), IsNil)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+	t.Assert(sf.Write([]string{" padded "}), IsNil)
+	t.Assert(sf.Close(), IsNil)
+
+	sstXML := readZipEntry(t, buf, "xl/sharedStrings.xml")
+	t.Assert(strings.Contains(sstXML, `<t xml:space="preserve"> padded </t>`), Equals, true)
+}
+
+// BenchmarkStreamFileSharedStrings compares output size between inline
+// strings (the streamer's historical default) and shared-string mode for a
+// sheet with many repeated values, which is the case shared strings are
+// meant to help.
+func BenchmarkStreamFileSharedStrings(b *testing.B) {
+	const rowCount = 5000
+	header := []string{"Category", "Status", "Note"}
+	categories := []string{"Electronics", "Groceries", "Apparel", "Toys"}
+	statuses := []string{"Open", "Closed", "Pending"}
+
+	run := func(b *testing.B, useSharedStrings bool) {
+		for i := 0; i < b.N; i++ {
+			buf := &bytes.Buffer{}
+			builder := NewStreamFileBuilder(buf)
+			if useSharedStrings {
+				if err := builder.UseSharedStrings(1000); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if err := builder.AddSheet("Sheet1", header, nil); err != nil {
+				b.Fatal(err)
+			}
+			sf, err := builder.Build()
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := sf.NextSheet(); err != nil {
+				b.Fatal(err)
+			}
+			for r := 0; r < rowCount; r++ {
+				row := []string{
+					categories[r%len(categories)],
+					statuses[r%len(statuses)],
+					fmt.Sprintf("row %d note", r),
+				}
+				if err := sf.Write(row); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if err := sf.Close(); err != nil {
+				b.Fatal(err)
+			}
+			b.ReportMetric(float64(buf.Len()), "bytes/op-size")
+		}
+	}
+
+	b.Run("InlineStrings", func(b *testing.B) { run(b, false) })
+	b.Run("SharedStrings", func(b *testing.B) { run(b, true) })
+}
@@ -0,0 +1,128 @@
+package xlsx
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// StreamSheetOptions configures the "polish" sheet-level features that must
+// be known before any rows are streamed: an auto-filter range, a frozen
+// header row/column pane, and the merged-cell ranges to apply once the sheet
+// is complete. Pass it to AddSheetS in place of AddSheet's plain header/
+// cellTypes arguments.
+type StreamSheetOptions struct {
+	Headers        []string
+	CellTypes      []*CellType
+	AutoFilterRef  string // e.g. "A1:D1"; empty disables auto-filter
+	FreezeTopRow   bool
+	FreezeFirstCol bool
+	MergeCells     []CellRange
+}
+
+// CellRange is an inclusive top-left/bottom-right cell reference pair, e.g.
+// {TopLeft: "A1", BottomRight: "B2"}.
+type CellRange struct {
+	TopLeft     string
+	BottomRight string
+}
+
+// AddSheetS is AddSheet plus the sheet-view and merge options described by
+// StreamSheetOptions. It exists instead of adding more positional parameters
+// to AddSheet because the option set here only makes sense together and is
+// expected to keep growing.
+func (sb *StreamFileBuilder) AddSheetS(name string, opts StreamSheetOptions) error {
+	if err := sb.AddSheet(name, opts.Headers, opts.CellTypes); err != nil {
+		return err
+	}
+	if sb.sheetViews == nil {
+		sb.sheetViews = map[string]*streamSheetView{}
+	}
+	sb.sheetViews[name] = &streamSheetView{
+		autoFilterRef:  opts.AutoFilterRef,
+		freezeTopRow:   opts.FreezeTopRow,
+		freezeFirstCol: opts.FreezeFirstCol,
+		merges:         opts.MergeCells,
+	}
+	return nil
+}
+
+// streamSheetView holds the per-sheet view/merge state declared via
+// AddSheetS plus any merges recorded later through StreamFile.MergeCells.
+type streamSheetView struct {
+	autoFilterRef  string
+	freezeTopRow   bool
+	freezeFirstCol bool
+	merges         []CellRange
+}
+
+// sheetViewXML renders the <sheetView> element for a sheet's frozen-pane
+// configuration, or "" if neither freeze option was set.
+func (v *streamSheetView) sheetViewXML() string {
+	if !v.freezeTopRow && !v.freezeFirstCol {
+		return ""
+	}
+	var ySplit, xSplit int
+	var topLeft string
+	var activePane string
+	switch {
+	case v.freezeTopRow && v.freezeFirstCol:
+		ySplit, xSplit, topLeft, activePane = 1, 1, "B2", "bottomRight"
+	case v.freezeTopRow:
+		ySplit, topLeft, activePane = 1, "A2", "bottomLeft"
+	default:
+		xSplit, topLeft, activePane = 1, "B1", "topRight"
+	}
+	return fmt.Sprintf(
+		`<sheetViews><sheetView workbookViewId="0"><pane xSplit="%d" ySplit="%d" topLeftCell="%s" activePane="%s" state="frozen"/></sheetView></sheetViews>`,
+		xSplit, ySplit, topLeft, activePane,
+	)
+}
+
+// autoFilterXML renders the <autoFilter> element, or "" if none was set.
+func (v *streamSheetView) autoFilterXML() string {
+	if v.autoFilterRef == "" {
+		return ""
+	}
+	return fmt.Sprintf(`<autoFilter ref="%s"/>`, v.autoFilterRef)
+}
+
+// mergeCellsXML renders the <mergeCells> block for every merge recorded on
+// the sheet, or "" if there are none. Per schema, this must be written after
+// </sheetData> and before <conditionalFormatting>.
+func (v *streamSheetView) mergeCellsXML() string {
+	if len(v.merges) == 0 {
+		return ""
+	}
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<mergeCells count="%d">`, len(v.merges))
+	for _, m := range v.merges {
+		fmt.Fprintf(buf, `<mergeCell ref="%s:%s"/>`, m.TopLeft, m.BottomRight)
+	}
+	buf.WriteString(`</mergeCells>`)
+	return buf.String()
+}
+
+// MergeCells records that the rectangular range from topLeft to
+// bottomRight should be merged in the sheet currently being streamed. It may
+// be called at any point while streaming that sheet's rows, but the range
+// must not start above the most recently written row.
+func (sf *StreamFile) MergeCells(topLeft, bottomRight string) error {
+	view := sf.builder.sheetViews[sf.currentSheetName]
+	if view == nil {
+		view = &streamSheetView{}
+		if sf.builder.sheetViews == nil {
+			sf.builder.sheetViews = map[string]*streamSheetView{}
+		}
+		sf.builder.sheetViews[sf.currentSheetName] = view
+	}
+
+	_, topRow, err := GetCoordsFromCellIDString(topLeft)
+	if err != nil {
+		return err
+	}
+	if sf.currentSheet != nil && topRow < sf.currentSheet.maxRowWritten-1 {
+		return fmt.Errorf("xlsx: cannot merge %s:%s, row %d has already been written", topLeft, bottomRight, topRow+1)
+	}
+	view.merges = append(view.merges, CellRange{TopLeft: topLeft, BottomRight: bottomRight})
+	return nil
+}
@@ -0,0 +1,77 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamSheetViewSuite struct{}
+
+var _ = Suite(&StreamSheetViewSuite{})
+
+func (s *StreamSheetViewSuite) TestAddSheetSWritesFreezeAutoFilterAndMerges(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheetS("Sheet1", StreamSheetOptions{
+		Headers:       []string{"Token", "Name"},
+		AutoFilterRef: "A1:B1",
+		FreezeTopRow:  true,
+		MergeCells:    []CellRange{{TopLeft: "A1", BottomRight: "B1"}},
+	}), IsNil)
+
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+	t.Assert(sf.Close(), IsNil)
+
+	sheetXML := readZipEntry(t, buf, "xl/worksheets/sheet1.xml")
+	t.Assert(strings.Contains(sheetXML, `state="frozen"`), Equals, true)
+	t.Assert(strings.Contains(sheetXML, `<autoFilter ref="A1:B1"/>`), Equals, true)
+	t.Assert(strings.Contains(sheetXML, `<mergeCell ref="A1:B1"/>`), Equals, true)
+
+	// Per schema, <sheetData> may only contain <row> children, and
+	// <autoFilter>/<mergeCells> must both come after it closes.
+	sheetDataClose := strings.Index(sheetXML, `</sheetData>`)
+	autoFilterPos := strings.Index(sheetXML, `<autoFilter`)
+	mergeCellsPos := strings.Index(sheetXML, `<mergeCells`)
+	t.Assert(sheetDataClose, Not(Equals), -1)
+	t.Assert(autoFilterPos, Not(Equals), -1)
+	t.Assert(mergeCellsPos, Not(Equals), -1)
+	t.Assert(autoFilterPos > sheetDataClose, Equals, true)
+	t.Assert(mergeCellsPos > autoFilterPos, Equals, true)
+}
+
+func (s *StreamSheetViewSuite) TestMergeCellsRejectsRowsAlreadyWritten(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Token"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil) // writes the header row, row 1
+
+	t.Assert(sf.Write([]string{"123"}), IsNil) // writes row 2
+
+	err = sf.MergeCells("A1", "A1")
+	t.Assert(err, ErrorMatches, "xlsx: cannot merge.*already been written")
+}
+
+func readZipEntry(t *C, buf *bytes.Buffer, name string) string {
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	t.Assert(err, IsNil)
+	for _, f := range zr.File {
+		if f.Name == name {
+			rc, err := f.Open()
+			t.Assert(err, IsNil)
+			data, err := io.ReadAll(rc)
+			t.Assert(err, IsNil)
+			rc.Close()
+			return string(data)
+		}
+	}
+	t.Fatalf("zip entry %s not found", name)
+	return ""
+}
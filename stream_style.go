@@ -0,0 +1,69 @@
+package xlsx
+
+// AddStyle registers style on the workbook's styles.xml ahead of any rows
+// being streamed and returns the stable styleID that StreamCell.StyleID (and
+// StreamFile.WriteCells) should use to reference it. The plain Write path
+// always uses the default style; AddStyle lets callers stream cells with
+// arbitrary fonts, fills, borders, and alignment.
+//
+// AddStyle must be called before Build; calling it afterwards returns
+// BuiltStreamFileBuilderError, matching AddSheet's behavior.
+func (sb *StreamFileBuilder) AddStyle(style *Style) (int, error) {
+	if sb.built {
+		return 0, BuiltStreamFileBuilderError
+	}
+	styleID := sb.nextStyleID()
+	sb.pendingStyles = append(sb.pendingStyles, style)
+	sb.registerStyleID(styleID)
+	return styleID, nil
+}
+
+// AddNumberFormat registers a custom number format code (e.g.
+// "0.00%;[Red]-0.00%") and returns the numFmtID a Style's Format field can
+// reference through its NumFmtID field. Built-in XLSX number formats occupy
+// IDs 0-163, so custom codes are allocated starting at 164, in registration
+// order. The returned ID is not a style ID and cannot be used as a
+// StreamCell.StyleID.
+func (sb *StreamFileBuilder) AddNumberFormat(code string) (int, error) {
+	if sb.built {
+		return 0, BuiltStreamFileBuilderError
+	}
+	const firstCustomNumFmtID = 164
+	numFmtID := firstCustomNumFmtID + len(sb.pendingNumberFormats)
+	sb.pendingNumberFormats = append(sb.pendingNumberFormats, numberFormat{id: numFmtID, code: code})
+	return numFmtID, nil
+}
+
+// numberFormat pairs a custom numFmtId with the format code it represents,
+// pending being written into styles.xml's <numFmts> by StreamFile.Close.
+type numberFormat struct {
+	id   int
+	code string
+}
+
+// nextStyleID predicts the cellXfs index the next AddStyle call will land
+// on, continuing after the built-in defaults (initMaxStyleId) and any
+// previous AddStyle call.
+func (sb *StreamFileBuilder) nextStyleID() int {
+	return sb.maxStyleID() + 1
+}
+
+// maxStyleID returns the highest cellXfs index claimed so far, whether by
+// the built-in defaults or a previous AddStyle call.
+func (sb *StreamFileBuilder) maxStyleID() int {
+	max := initMaxStyleId
+	for id := range sb.registeredStyles {
+		if id > max {
+			max = id
+		}
+	}
+	return max
+}
+
+// registerStyleID records styleID as valid for use as a StreamCell.StyleID.
+func (sb *StreamFileBuilder) registerStyleID(styleID int) {
+	if sb.registeredStyles == nil {
+		sb.registeredStyles = map[int]bool{}
+	}
+	sb.registeredStyles[styleID] = true
+}
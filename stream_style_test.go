@@ -0,0 +1,57 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamStyleSuite struct{}
+
+var _ = Suite(&StreamStyleSuite{})
+
+func (s *StreamStyleSuite) TestAddStyleAndAddNumberFormatAreWritten(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A"}, nil), IsNil)
+
+	numFmtID, err := builder.AddNumberFormat("0.00%")
+	t.Assert(err, IsNil)
+	t.Assert(numFmtID, Equals, 164)
+
+	styleID, err := builder.AddStyle(&Style{Font: Font{Bold: true}, NumFmtID: numFmtID})
+	t.Assert(err, IsNil)
+	t.Assert(styleID, Equals, initMaxStyleId+1)
+
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+	t.Assert(sf.WriteCells([]StreamCell{NewNumericStreamCell(0.5, styleID)}), IsNil)
+	t.Assert(sf.Close(), IsNil)
+
+	stylesXML := readZipEntry(t, buf, "xl/styles.xml")
+	t.Assert(strings.Contains(stylesXML, `<numFmt numFmtId="164" formatCode="0.00%"/>`), Equals, true)
+	t.Assert(strings.Contains(stylesXML, `<b/>`), Equals, true)
+
+	// One registered style must bump the declared counts past the
+	// built-in defaults, matching the actual number of <font>/<fill>/
+	// <border> children written.
+	t.Assert(strings.Contains(stylesXML, `<fonts count="2">`), Equals, true)
+	t.Assert(strings.Contains(stylesXML, `<fills count="3">`), Equals, true)
+	t.Assert(strings.Contains(stylesXML, `<borders count="2">`), Equals, true)
+}
+
+func (s *StreamStyleSuite) TestStyleMethodsErrorAfterBuild(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"A"}, nil), IsNil)
+	_, err := builder.Build()
+	t.Assert(err, IsNil)
+
+	_, err = builder.AddStyle(&Style{})
+	t.Assert(err, Equals, BuiltStreamFileBuilderError)
+
+	_, err = builder.AddNumberFormat("0.00")
+	t.Assert(err, Equals, BuiltStreamFileBuilderError)
+}
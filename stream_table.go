@@ -0,0 +1,116 @@
+package xlsx
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// TableOptions configures the appearance of a table created with
+// StreamFileBuilder.AddTable.
+type TableOptions struct {
+	StyleName      string // e.g. "TableStyleMedium9"; empty uses the Excel default
+	ShowRowStripes bool
+}
+
+// streamTable records a pending Excel table for one sheet, to be emitted as
+// xl/tables/tableN.xml once the sheet's used range is known.
+type streamTable struct {
+	name    string
+	ref     string // empty until resolved from the header row / MarkTableRegion
+	headers []string
+	opts    TableOptions
+}
+
+// AddTable declares that the rows streamed to sheetName should be exposed as
+// a real Excel table once the sheet is complete. If ref is empty, the used
+// range is derived from the header row passed to AddSheet and the row range
+// recorded via StreamFile.MarkTableRegion.
+func (sb *StreamFileBuilder) AddTable(sheetName, tableName, ref string, opts *TableOptions) error {
+	if sb.built {
+		return BuiltStreamFileBuilderError
+	}
+	if opts == nil {
+		opts = &TableOptions{}
+	}
+	if sb.tables == nil {
+		sb.tables = map[string]*streamTable{}
+	}
+	sb.tables[sheetName] = &streamTable{
+		name:    tableName,
+		ref:     ref,
+		headers: sb.sheetHeaders(sheetName),
+		opts:    *opts,
+	}
+	return nil
+}
+
+// sheetHeaders returns the header row previously passed to AddSheet for
+// sheetName, used to derive a table's tableColumns.
+func (sb *StreamFileBuilder) sheetHeaders(sheetName string) []string {
+	return sb.sheetHeadersMap[sheetName]
+}
+
+// MarkTableRegion records the first and last row (1-indexed, inclusive of
+// the header row) that make up the table declared for the sheet currently
+// being streamed, so AddTable's ref can be derived when the caller didn't
+// supply one up front.
+func (sf *StreamFile) MarkTableRegion(startRow, endRow int) error {
+	table := sf.builder.tables[sf.currentSheetName]
+	if table == nil {
+		return fmt.Errorf("xlsx: MarkTableRegion called on sheet %q with no AddTable declaration", sf.currentSheetName)
+	}
+	if table.ref == "" {
+		lastCol := GetCellIDStringFromCoords(len(table.headers)-1, 0)
+		firstCol := GetCellIDStringFromCoords(0, 0)
+		table.ref = fmt.Sprintf("%s%d:%s%d", stripRowDigits(firstCol), startRow, stripRowDigits(lastCol), endRow)
+	}
+	return nil
+}
+
+// stripRowDigits returns the column-letter prefix of a cell reference like
+// "AB12", discarding the row number so it can be recombined with a
+// different row.
+func stripRowDigits(cellRef string) string {
+	i := 0
+	for i < len(cellRef) && (cellRef[i] < '0' || cellRef[i] > '9') {
+		i++
+	}
+	return cellRef[:i]
+}
+
+// tableXML renders the xl/tables/tableN.xml part for a declared table.
+func tableXML(id int, t *streamTable) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+
+		`<table xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" `+
+		`id="%d" name="%s" displayName="%s" ref="%s" totalsRowShown="0">`,
+		id, escapeXMLAttr(t.name), escapeXMLAttr(t.name), escapeXMLAttr(t.ref))
+	buf.WriteString(`<autoFilter ref="` + escapeXMLAttr(t.ref) + `"/>`)
+	fmt.Fprintf(buf, `<tableColumns count="%d">`, len(t.headers))
+	for i, h := range t.headers {
+		fmt.Fprintf(buf, `<tableColumn id="%d" name="%s"/>`, i+1, escapeXMLAttr(h))
+	}
+	buf.WriteString(`</tableColumns>`)
+	styleName := t.opts.StyleName
+	if styleName == "" {
+		styleName = "TableStyleMedium2"
+	}
+	fmt.Fprintf(buf, `<tableStyleInfo name="%s" showRowStripes="%d"/>`, styleName, boolToInt(t.opts.ShowRowStripes))
+	buf.WriteString(`</table>`)
+	return buf.String()
+}
+
+// tablePartsXML renders the <tableParts> element that must be appended to a
+// sheet's XML, just before </worksheet>, once its table's region is final.
+func tablePartsXML(t *streamTable, relID string) string {
+	return fmt.Sprintf(`<tableParts count="1"><tablePart r:id="%s"/></tableParts>`, relID)
+}
+
+// tableContentTypeOverride is the [Content_Types].xml Override entry
+// required for every xl/tables/tableN.xml part.
+func tableContentTypeOverride(partName string) string {
+	return fmt.Sprintf(
+		`<Override PartName="/%s" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"/>`,
+		partName,
+	)
+}
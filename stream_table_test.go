@@ -0,0 +1,46 @@
+package xlsx
+
+import (
+	"bytes"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type StreamTableSuite struct{}
+
+var _ = Suite(&StreamTableSuite{})
+
+func (s *StreamTableSuite) TestAddTableWithMarkedRegion(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Token", "Name"}, nil), IsNil)
+	t.Assert(builder.AddTable("Sheet1", "Items", "", nil), IsNil)
+
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+	t.Assert(sf.Write([]string{"123", "Taco"}), IsNil)
+	t.Assert(sf.MarkTableRegion(1, 2), IsNil)
+	t.Assert(sf.Close(), IsNil)
+
+	sheetXML := readZipEntry(t, buf, "xl/worksheets/sheet1.xml")
+	t.Assert(strings.Contains(sheetXML, `<tableParts count="1">`), Equals, true)
+
+	tableXMLOut := readZipEntry(t, buf, "xl/tables/table1.xml")
+	t.Assert(strings.Contains(tableXMLOut, `ref="A1:B2"`), Equals, true)
+	t.Assert(strings.Contains(tableXMLOut, `name="Items"`), Equals, true)
+	t.Assert(strings.Contains(tableXMLOut, `<tableColumn id="1" name="Token"/>`), Equals, true)
+}
+
+func (s *StreamTableSuite) TestMarkTableRegionWithoutAddTableErrors(t *C) {
+	buf := &bytes.Buffer{}
+	builder := NewStreamFileBuilder(buf)
+	t.Assert(builder.AddSheet("Sheet1", []string{"Token"}, nil), IsNil)
+	sf, err := builder.Build()
+	t.Assert(err, IsNil)
+	t.Assert(sf.NextSheet(), IsNil)
+
+	err = sf.MarkTableRegion(1, 2)
+	t.Assert(err, ErrorMatches, "xlsx: MarkTableRegion called.*no AddTable declaration")
+}
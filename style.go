@@ -0,0 +1,174 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+)
+
+// Style describes the font, fill, border, alignment, and number format of a
+// cell. It's the payload StreamFileBuilder.AddStyle registers so streamed
+// cells can use fonts, fills, borders, and custom number formats; the plain
+// Write path always uses the default style.
+type Style struct {
+	Font      Font
+	Fill      Fill
+	Border    Border
+	Alignment Alignment
+	NumFmtID  int
+}
+
+// Font describes a cell's font styling.
+type Font struct {
+	Name   string
+	Size   float64
+	Bold   bool
+	Italic bool
+	Color  string // RGB or ARGB hex
+}
+
+// Fill describes a cell's background fill.
+type Fill struct {
+	PatternType string // e.g. "solid"
+	FgColor     string
+	BgColor     string
+}
+
+// Border describes a cell's border, one style name (e.g. "thin") per side;
+// an empty side has no border.
+type Border struct {
+	Left   string
+	Right  string
+	Top    string
+	Bottom string
+}
+
+// Alignment describes a cell's text alignment.
+type Alignment struct {
+	Horizontal string
+	Vertical   string
+	WrapText   bool
+}
+
+// writeStylesPart renders xl/styles.xml for a workbook whose only styles
+// are the built-in defaults (cellXfs indices 0 through initMaxStyleId) plus
+// any custom number formats and styles registered via
+// StreamFileBuilder.AddNumberFormat/AddStyle.
+func writeStylesPart(zw *zip.Writer, styles []*Style, numFmts []numberFormat) error {
+	w, err := zw.Create("xl/styles.xml")
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	buf.WriteString(`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if len(numFmts) > 0 {
+		fmt.Fprintf(buf, `<numFmts count="%d">`, len(numFmts))
+		for _, nf := range numFmts {
+			fmt.Fprintf(buf, `<numFmt numFmtId="%d" formatCode="%s"/>`, nf.id, escapeXMLAttr(nf.code))
+		}
+		buf.WriteString(`</numFmts>`)
+	}
+
+	fmt.Fprintf(buf, `<fonts count="%d">`, 1+len(styles))
+	buf.WriteString(`<font><sz val="11"/><name val="Calibri"/></font>`)
+	for _, s := range styles {
+		fmt.Fprintf(buf, `<font>`)
+		if s.Font.Size != 0 {
+			fmt.Fprintf(buf, `<sz val="%g"/>`, s.Font.Size)
+		}
+		if s.Font.Name != "" {
+			fmt.Fprintf(buf, `<name val="%s"/>`, escapeXMLAttr(s.Font.Name))
+		}
+		if s.Font.Bold {
+			buf.WriteString(`<b/>`)
+		}
+		if s.Font.Italic {
+			buf.WriteString(`<i/>`)
+		}
+		if s.Font.Color != "" {
+			fmt.Fprintf(buf, `<color rgb="%s"/>`, normalizeRGB(s.Font.Color))
+		}
+		buf.WriteString(`</font>`)
+	}
+	buf.WriteString(`</fonts>`)
+
+	fmt.Fprintf(buf, `<fills count="%d">`, 2+len(styles))
+	buf.WriteString(`<fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>`)
+	for _, s := range styles {
+		if s.Fill.PatternType == "" {
+			buf.WriteString(`<fill><patternFill patternType="none"/></fill>`)
+			continue
+		}
+		fmt.Fprintf(buf, `<fill><patternFill patternType="%s">`, s.Fill.PatternType)
+		if s.Fill.FgColor != "" {
+			fmt.Fprintf(buf, `<fgColor rgb="%s"/>`, normalizeRGB(s.Fill.FgColor))
+		}
+		if s.Fill.BgColor != "" {
+			fmt.Fprintf(buf, `<bgColor rgb="%s"/>`, normalizeRGB(s.Fill.BgColor))
+		}
+		buf.WriteString(`</patternFill></fill>`)
+	}
+	buf.WriteString(`</fills>`)
+
+	fmt.Fprintf(buf, `<borders count="%d">`, 1+len(styles))
+	buf.WriteString(`<border><left/><right/><top/><bottom/><diagonal/></border>`)
+	for _, s := range styles {
+		buf.WriteString(`<border>`)
+		writeBorderSide(buf, "left", s.Border.Left)
+		writeBorderSide(buf, "right", s.Border.Right)
+		writeBorderSide(buf, "top", s.Border.Top)
+		writeBorderSide(buf, "bottom", s.Border.Bottom)
+		buf.WriteString(`<diagonal/></border>`)
+	}
+	buf.WriteString(`</borders>`)
+
+	buf.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+
+	fmt.Fprintf(buf, `<cellXfs count="%d">`, initMaxStyleId+1+len(styles))
+	for i := 0; i <= initMaxStyleId; i++ {
+		buf.WriteString(`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>`)
+	}
+	for i, s := range styles {
+		fontID := i + 1
+		fillID := 0
+		if s.Fill.PatternType != "" {
+			fillID = i + 2
+		}
+		borderID := i + 1
+		applyAlignment := s.Alignment != (Alignment{})
+		fmt.Fprintf(buf, `<xf numFmtId="%d" fontId="%d" fillId="%d" borderId="%d" xfId="0"`,
+			s.NumFmtID, fontID, fillID, borderID)
+		if applyAlignment {
+			buf.WriteString(` applyAlignment="1"`)
+		}
+		if applyAlignment {
+			fmt.Fprintf(buf, `><alignment horizontal="%s" vertical="%s" wrapText="%d"/></xf>`,
+				s.Alignment.Horizontal, s.Alignment.Vertical, boolToInt(s.Alignment.WrapText))
+		} else {
+			buf.WriteString(`/>`)
+		}
+	}
+	buf.WriteString(`</cellXfs>`)
+
+	buf.WriteString(`</styleSheet>`)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func writeBorderSide(buf *bytes.Buffer, side, style string) {
+	if style == "" {
+		fmt.Fprintf(buf, `<%s/>`, side)
+		return
+	}
+	fmt.Fprintf(buf, `<%s style="%s"/>`, side, style)
+}
+
+// escapeXMLAttr escapes s for use inside a double-quoted XML attribute
+// value.
+func escapeXMLAttr(s string) string {
+	buf := &bytes.Buffer{}
+	xmlEscapeTo(buf, s)
+	return buf.String()
+}
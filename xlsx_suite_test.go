@@ -0,0 +1,12 @@
+package xlsx
+
+import (
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+// Test is the gocheck entry point testing.T hands off to; every *Suite
+// registered with Suite(...) across the package's _test.go files runs under
+// this single top-level test.
+func Test(t *testing.T) { TestingT(t) }